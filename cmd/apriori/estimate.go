@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/loader"
+)
+
+// runEstimate reports the expected itemset counts per level without mining rules
+func runEstimate(arguments []string) {
+	fs := flag.NewFlagSet("estimate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println("Usage: apriori estimate <csv_file> <min_support>")
+		fmt.Println("  - csv_file: Path to the CSV file with columns for Basket and Item")
+		fmt.Println("  - min_support: Minimum support threshold to evaluate")
+	}
+	_ = fs.Parse(arguments) // ExitOnError exits on invalid flags
+
+	args := fs.Args()
+	if len(args) < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	inputFile := args[0]
+	minSupport, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		log.Fatalf("Invalid min_support value: %v", err)
+	}
+
+	dataset, err := loader.LoadFromCSV(inputFile)
+	if err != nil {
+		log.Fatalf("Error loading dataset: %v", err)
+	}
+
+	fmt.Printf("Found %d transactions and %d unique items\n",
+		len(dataset.Transactions), len(dataset.UniqueItems))
+	fmt.Printf("Estimate for minSupport=%.4f:\n", minSupport)
+	fmt.Printf("%-8s %-15s %-15s\n", "Level", "Candidates", "Frequent")
+
+	for _, estimate := range algorithm.EstimateLevels(dataset, minSupport) {
+		frequent := "unknown"
+		if estimate.Counted {
+			frequent = strconv.Itoa(estimate.Frequent)
+		}
+		fmt.Printf("%-8d %-15d %-15s\n", estimate.Level, estimate.Candidates, frequent)
+	}
+}
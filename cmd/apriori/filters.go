@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// ruleFilters are post-generation checks a rule must pass to be kept
+type ruleFilters []func(models.AssociationRule) bool
+
+// keep reports whether a rule passes every filter
+func (filters ruleFilters) keep(rule models.AssociationRule) bool {
+	for _, filter := range filters {
+		if !filter(rule) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply returns the rules that pass every filter
+func (filters ruleFilters) apply(rules []models.AssociationRule) []models.AssociationRule {
+	if len(filters) == 0 {
+		return rules
+	}
+
+	kept := make([]models.AssociationRule, 0, len(rules))
+	for _, rule := range rules {
+		if filters.keep(rule) {
+			kept = append(kept, rule)
+		}
+	}
+	return kept
+}
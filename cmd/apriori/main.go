@@ -1,29 +1,137 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
 	"github.com/RiceaRaul/AprioriGO/internal/loader"
+	"github.com/RiceaRaul/AprioriGO/internal/models"
 	"github.com/RiceaRaul/AprioriGO/internal/output"
 )
 
 func main() {
-	// Parse command line arguments
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: apriori <csv_file> [min_support] [min_confidence] [max_length]")
-		fmt.Println("  - csv_file: Path to the CSV file with columns for Basket and Item")
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "mine":
+			runMine(os.Args[2:])
+			return
+		case "estimate":
+			runEstimate(os.Args[2:])
+			return
+		case "merge-rules":
+			runMergeRules(os.Args[2:])
+			return
+		case "query":
+			runQuery(os.Args[2:])
+			return
+		case "tune":
+			runTune(os.Args[2:])
+			return
+		case "rules":
+			runRules(os.Args[2:])
+			return
+		}
+	}
+
+	runMine(os.Args[1:])
+}
+
+// runMine mines itemsets and rules from a CSV file and writes them to disk
+func runMine(arguments []string) {
+	// Parse command line flags
+	fs := flag.NewFlagSet("apriori", flag.ExitOnError)
+	noItemsets := fs.Bool("no-itemsets", false, "Skip writing the frequent itemsets file")
+	noRules := fs.Bool("no-rules", false, "Skip generating and writing association rules")
+	outDir := fs.String("out-dir", ".", "Directory to write output files to")
+	rulesFormat := fs.String("rules-format", "csv", "Output format for rules: csv, jsonl or parquet")
+	itemsetsFormat := fs.String("itemsets-format", "csv", "Output format for itemsets: csv or parquet")
+	saveResult := fs.String("save-result", "", "Also save itemsets, rules and parameters to this file for reloading")
+	minAllConfidence := fs.Float64("min-all-confidence", 0, "Drop itemsets whose support divided by the highest support of any of their items is below this (0 to disable)")
+	minLength := fs.Int("min-length", 0, "Only report itemsets with at least this many items; equal to max_length gives one exact length")
+	lengthSupport := fs.String("length-support", "", "Comma-separated min_support per itemset length, e.g. 0.01,0.01,0.002")
+	normalizeItems := fs.Bool("normalize-items", false, "Lowercase item names and collapse whitespace before counting")
+	maxBasket := fs.Int("max-basket", 0, "Drop baskets with more than this many distinct items (0 for no cap)")
+	truncateBaskets := fs.Bool("truncate-baskets", false, "Truncate baskets over -max-basket to their first items instead of dropping them")
+	oneHot := fs.Bool("onehot", false, "Read a one-hot CSV: item names in the header, one 0/1 row per transaction")
+	wide := fs.Bool("wide", false, "Read one basket per row: the basket ID followed by any number of item columns")
+	itemSeparator := fs.String("item-separator", "", "With -wide, split item cells holding several items on this separator, e.g. ;")
+	encodingName := fs.String("encoding", "utf-8", "Character encoding of the input CSV: utf-8 or latin-1; a UTF-8 byte order mark is always dropped")
+	nullTokens := fs.String("null-tokens", "", "Comma-separated item values meaning no item, e.g. NULL,NA; skipped like empty cells")
+	halfLife := fs.Duration("half-life", 0, "With -session-window, weight sessions by recency so one this old counts half as much as the latest, e.g. 720h")
+	sessionWindow := fs.Duration("session-window", 0, "Read timestamp,user,item rows and group each user's events into sessions split at gaps longer than this, e.g. 30m")
+	sampleFraction := fs.Float64("sample", 0, "Mine a random fraction of the transactions, e.g. 0.1 (0 mines all)")
+	seed := fs.Int64("seed", 1, "Random seed for -sample; the same seed selects the same transactions")
+	excel := fs.Bool("excel", false, "Prepend a UTF-8 byte order mark to CSV output so Excel shows accented item names correctly")
+	sequential := fs.Bool("sequential", false, "Keep the order of each basket's rows and write ordered A => B pair rules, counting only baskets where A comes before B")
+	maximalRules := fs.Bool("maximal-rules", false, "Generate rules only from maximal frequent itemsets")
+	streamRules := fs.Bool("stream-rules", false, "Write rules to disk as they are generated instead of collecting them first")
+	algorithmName := fs.String("algorithm", "auto", "Itemset mining algorithm: apriori, eclat, fpgrowth, or auto to mine with apriori and print advice for the dataset")
+	memoryBudget := fs.Int("memory-budget", 0, "With -algorithm auto, approximate memory budget in MB; mine with eclat when Apriori's candidates are estimated to exceed it (0 for no budget)")
+	singlePass := fs.Bool("single-pass", false, "Count every Apriori level in one scan of the transactions instead of one scan per level")
+	levelStats := fs.Bool("level-stats", false, "Print time, candidates and frequent itemsets per Apriori level")
+	taxonomyFile := fs.String("cross-category", "", "Item,category CSV; drop rules whose items all share one category")
+	consequents := fs.String("consequents", "", "Comma-separated items; keep only rules whose consequent items are all among them")
+	blocklistFile := fs.String("blocklist", "", "File of rules to drop, one {antecedent} => {consequent} per line")
+	maxItemsets := fs.Int("max-itemsets", 0, "Exit with an error instead of writing output when more than this many frequent itemsets are found (0 for no cap)")
+	maxRules := fs.Int("max-rules", 0, "Exit with an error instead of writing output when more than this many rules are generated (0 for no cap)")
+	minLift := fs.Float64("min-lift", 0, "Drop rules with a lift below this, e.g. 1 to keep only positively associated rules (0 for none)")
+	minLeverage := fs.Float64("min-leverage", -1, "Drop rules with a leverage below this, e.g. 0 to keep only positively associated rules (-1 for none)")
+	perfectMinCount := fs.Int("perfect-min-count", 0, "Drop rules with confidence 1 whose antecedent is in fewer than this many baskets")
+	maxAntecedent := fs.Int("max-antecedent", 0, "Maximum number of items in a rule antecedent (0 for no limit)")
+	withItems := fs.String("with-items", "", "Comma-separated items; keep only rules mentioning one of them")
+	itemsSide := fs.String("items-side", "either", "Rule side -with-items applies to: antecedent, consequent or either")
+	noSupportCount := fs.Bool("no-support-count", false, "Leave the support_count column out of the itemsets CSV")
+	sortBy := fs.String("sort-by", "", "Sort rules by support, confidence, lift, leverage or conviction, highest first; a comma-separated list breaks ties in order")
+	scoreWeights := fs.String("score-weights", "", "Score rules by a weighted sum of normalized metrics, e.g. confidence=0.5,lift=0.3,support=0.2 (revenue also accepted); sort with -sort-by score")
+	top := fs.Int("top", 0, "Keep only the N best rules by -sort-by (confidence if unset); 0 keeps all")
+	ruleColumns := fs.String("columns", "", "Comma-separated metric columns for the rules CSV, e.g. support,confidence,lift")
+	rulesLayout := fs.String("rules-layout", "default", "Column layout of the rules CSV: default, or mlxtend for the columns of mlxtend's association_rules")
+	nonFinite := fs.String("non-finite", "inf", "How infinite or NaN metrics are written in CSV output: inf (inf, -inf, nan), empty or null")
+	itemsetType := fs.String("itemset-type", "all", "Itemsets written to the itemsets file: all, closed (no superset with the same support) or maximal (no frequent superset)")
+	itemStyle := fs.String("item-style", "brace", "Item list rendering in CSV output: brace ({a,b}), pipe (a|b) or json ([\"a\",\"b\"])")
+	cpuProfile := fs.String("cpuprofile", "", "Write a CPU profile of the run to this file")
+	memProfile := fs.String("memprofile", "", "Write a heap profile taken at the end of the run to this file")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: apriori [options] <csv_file> [min_support] [min_confidence] [max_length]")
+		fmt.Println("  - csv_file: Path to the CSV file with columns for Basket and Item, optionally gzip compressed, or - for standard input")
 		fmt.Println("  - min_support: Minimum support threshold (default: 0.01)")
 		fmt.Println("  - min_confidence: Minimum confidence threshold (default: 0.2)")
 		fmt.Println("  - max_length: Maximum itemset length (default: 5)")
+		fmt.Println("Commands:")
+		fmt.Println("  apriori mine [options] <csv_file> ...: Same as running apriori without a command")
+		fmt.Println("  apriori estimate <csv_file> <min_support>: Report expected itemset counts per level")
+		fmt.Println("  apriori merge-rules [-agg max] <out_csv> <in_csv>...: Merge rules files, combining duplicates")
+		fmt.Println("  apriori query <result_file>: Explore a result saved with -save-result interactively")
+		fmt.Println("  apriori tune [-target-itemsets 500] <csv_file>: Search for the min_support giving about that many itemsets")
+		fmt.Println("  apriori rules [-out rules.csv] <itemsets_csv> [min_confidence]: Generate rules from a saved itemsets file")
+		fmt.Println("Options:")
+		fs.SetOutput(os.Stdout)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(arguments) // ExitOnError exits on invalid flags
+
+	// Parse positional arguments
+	args := fs.Args()
+	if len(args) < 1 {
+		fs.Usage()
 		os.Exit(1)
 	}
 
 	// Get input file
-	inputFile := os.Args[1]
+	inputFile := args[0]
 
 	// Set parameters with defaults
 	minSupport := 0.01
@@ -31,29 +139,146 @@ func main() {
 	maxLen := 5
 
 	// Override from command line if provided
-	if len(os.Args) > 2 {
-		_, err := fmt.Sscanf(os.Args[2], "%f", &minSupport)
+	if len(args) > 1 {
+		_, err := fmt.Sscanf(args[1], "%f", &minSupport)
 		if err != nil {
 			log.Fatalf("Invalid min_support value: %v", err)
 		}
 	}
 
-	if len(os.Args) > 3 {
-		_, err := fmt.Sscanf(os.Args[3], "%f", &minConfidence)
+	if len(args) > 2 {
+		_, err := fmt.Sscanf(args[2], "%f", &minConfidence)
 		if err != nil {
 			log.Fatalf("Invalid min_confidence value: %v", err)
 		}
 	}
 
-	if len(os.Args) > 4 {
-		_, err := fmt.Sscanf(os.Args[4], "%d", &maxLen)
+	if len(args) > 3 {
+		_, err := fmt.Sscanf(args[3], "%d", &maxLen)
 		if err != nil {
 			log.Fatalf("Invalid max_length value: %v", err)
 		}
 	}
 
+	mineOptions := algorithm.MineOptions{
+		MinSupport:       minSupport,
+		MaxLength:        maxLen,
+		MinLength:        *minLength,
+		MinAllConfidence: *minAllConfidence,
+		SampleFraction:   *sampleFraction,
+		SinglePass:       *singlePass,
+		MemoryBudgetMB:   *memoryBudget,
+		Seed:             *seed,
+	}
+	algo, err := algorithm.ParseAlgorithm(*algorithmName)
+	if err != nil {
+		log.Fatalf("Invalid -algorithm value: %v", err)
+	}
+	mineOptions.Algorithm = algo
+	var lengthThresholds []float64
+	if *lengthSupport != "" {
+		thresholds, err := parseFloatList(*lengthSupport)
+		if err != nil {
+			log.Fatalf("Invalid -length-support value: %v", err)
+		}
+		mineOptions.LengthSupport = algorithm.WithLengthSupport(thresholds)
+		lengthThresholds = thresholds
+	}
+
+	if *saveResult != "" && *streamRules && *top == 0 {
+		log.Fatalf("-save-result needs every rule in memory and cannot be used with -stream-rules unless -top is set")
+	}
+
+	if *sequential && (*oneHot || *top > 0 || *streamRules) {
+		log.Fatalf("-sequential needs the row order of the input and every rule in memory and cannot be used with -onehot, -top or -stream-rules")
+	}
+
+	if *halfLife != 0 && *sessionWindow <= 0 {
+		log.Fatalf("-half-life needs session timestamps and can only be used with -session-window")
+	}
+
+	if *rulesFormat != "csv" && *rulesFormat != "jsonl" && *rulesFormat != "parquet" {
+		log.Fatalf("Invalid rules format %q: must be csv, jsonl or parquet", *rulesFormat)
+	}
+	if *itemsetsFormat != "csv" && *itemsetsFormat != "parquet" {
+		log.Fatalf("Invalid itemsets format %q: must be csv or parquet", *itemsetsFormat)
+	}
+	if *rulesFormat == "parquet" && *streamRules && *top == 0 {
+		log.Fatalf("-rules-format parquet writes every rule at once and cannot be used with -stream-rules unless -top is set")
+	}
+
+	var sortMetrics []algorithm.Metric
+	sortNames := *sortBy
+	if sortNames == "" {
+		sortNames = string(algorithm.MetricConfidence)
+	}
+	if *sortBy != "" || *top > 0 {
+		for _, name := range strings.Split(sortNames, ",") {
+			metric, err := algorithm.ParseMetric(strings.TrimSpace(name))
+			if err != nil {
+				log.Fatalf("Invalid -sort-by value: %v", err)
+			}
+			sortMetrics = append(sortMetrics, metric)
+		}
+		if *streamRules && *top == 0 {
+			log.Fatalf("-sort-by needs every rule in memory and cannot be used with -stream-rules unless -top is set")
+		}
+	}
+
+	var weights algorithm.ScoreWeights
+	if *scoreWeights != "" {
+		if weights, err = parseScoreWeights(*scoreWeights); err != nil {
+			log.Fatalf("Invalid -score-weights value: %v", err)
+		}
+		if *top > 0 || *streamRules {
+			log.Fatalf("-score-weights normalizes metrics over every rule and cannot be used with -top or -stream-rules")
+		}
+	} else if slices.Contains(sortMetrics, algorithm.MetricScore) {
+		log.Fatalf("-sort-by score needs -score-weights")
+	}
+
+	switch *itemsetType {
+	case "all", "closed", "maximal":
+	default:
+		log.Fatalf("Invalid -itemset-type value %q: must be all, closed or maximal", *itemsetType)
+	}
+
+	style, err := output.ParseItemStyle(*itemStyle)
+	if err != nil {
+		log.Fatalf("Invalid -item-style value: %v", err)
+	}
+	nonFiniteStyle, err := output.ParseNonFinite(*nonFinite)
+	if err != nil {
+		log.Fatalf("Invalid -non-finite value: %v", err)
+	}
+	layout, err := output.ParseRuleLayout(*rulesLayout)
+	if err != nil {
+		log.Fatalf("Invalid -rules-layout value: %v", err)
+	}
+	if layout == output.RuleLayoutMLxtend && *ruleColumns != "" {
+		log.Fatalf("-columns cannot be combined with -rules-layout mlxtend")
+	}
+	csvOptions := output.CSVOptions{BOM: *excel, OmitSupportCount: *noSupportCount, ItemStyle: style, NonFinite: nonFiniteStyle, Layout: layout}
+	if *ruleColumns != "" {
+		for _, column := range strings.Split(*ruleColumns, ",") {
+			csvOptions.Columns = append(csvOptions.Columns, strings.TrimSpace(column))
+		}
+		if err := output.ValidateRuleColumns(csvOptions.Columns); err != nil {
+			log.Fatalf("Invalid -columns value: %v", err)
+		}
+	} else if *scoreWeights != "" && layout != output.RuleLayoutMLxtend {
+		csvOptions.Columns = append(slices.Clone(output.DefaultRuleColumns), "score")
+	}
+
 	// Check if input file exists
-	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
+	if *wide && (*oneHot || *sessionWindow > 0) {
+		log.Fatalf("-wide cannot be combined with -onehot or -session-window")
+	}
+	if inputFile == "-" {
+		if *sessionWindow > 0 || *wide {
+			log.Fatalf("Standard input is only supported for basket,item and one-hot CSV data, not with -session-window or -wide")
+		}
+	} else if _, err := os.Stat(inputFile); os.IsNotExist(err) {
 		log.Fatalf("Input file %s does not exist", inputFile)
 	}
 
@@ -63,10 +288,50 @@ func main() {
 	fmt.Printf("Parameters: minSupport=%.4f, minConfidence=%.4f, maxLen=%d\n",
 		minSupport, minConfidence, maxLen)
 
+	stopProfiling := startProfiling(*cpuProfile, *memProfile)
+	defer stopProfiling()
+
 	// Load data
 	fmt.Println("Loading and transforming dataset...")
 	startLoadTime := time.Now()
-	dataset, err := loader.LoadFromCSV(inputFile)
+	loadOptions := loader.LoadOptions{
+		NormalizeItems:  *normalizeItems,
+		MaxBasketSize:   *maxBasket,
+		TruncateBaskets: *truncateBaskets,
+		KeepOrder:       *sequential,
+	}
+	if loadOptions.Encoding, err = loader.ParseEncoding(*encodingName); err != nil {
+		log.Fatalf("Invalid -encoding value: %v", err)
+	}
+	if *nullTokens != "" {
+		for _, token := range strings.Split(*nullTokens, ",") {
+			loadOptions.NullTokens = append(loadOptions.NullTokens, strings.TrimSpace(token))
+		}
+	}
+	var dataset *models.Dataset
+	var report *loader.LoadReport
+	if *oneHot && inputFile == "-" {
+		dataset, report, err = loader.LoadFromOneHotReader(os.Stdin, loadOptions)
+	} else if *oneHot {
+		dataset, report, err = loader.LoadFromOneHotCSVWithOptions(inputFile, loadOptions)
+	} else if *sessionWindow > 0 {
+		dataset, report, err = loader.LoadSessionsFromCSV(inputFile, loader.SessionOptions{
+			LoadOptions:     loadOptions,
+			TimestampColumn: 0,
+			UserColumn:      1,
+			ItemColumn:      2,
+			Window:          *sessionWindow,
+		})
+	} else if *wide {
+		dataset, report, err = loader.LoadFromWideCSV(inputFile, loader.WideOptions{
+			LoadOptions:   loadOptions,
+			ItemSeparator: *itemSeparator,
+		})
+	} else if inputFile == "-" {
+		dataset, report, err = loader.LoadFromReader(os.Stdin, loadOptions)
+	} else {
+		dataset, report, err = loader.LoadFromCSVWithOptions(inputFile, loadOptions)
+	}
 	if err != nil {
 		log.Fatalf("Error loading dataset: %v", err)
 	}
@@ -74,14 +339,103 @@ func main() {
 	fmt.Printf("Dataset loaded in %v\n", time.Since(startLoadTime))
 	fmt.Printf("Found %d transactions and %d unique items\n",
 		len(dataset.Transactions), len(dataset.UniqueItems))
+	for _, warning := range loader.DatasetValidate(dataset) {
+		fmt.Printf("Warning: %s\n", warning.Message)
+	}
+	if mineOptions.Algorithm == algorithm.AlgorithmAuto {
+		density := algorithm.AnalyzeDensity(dataset)
+		fmt.Printf("Density %.4f (%.1f items per basket, item skew %.2f): %s\n",
+			density.Density, density.AverageBasketSize, density.Skew, density.Recommendation)
+	}
+	if *normalizeItems {
+		fmt.Printf("Normalization merged %d item name variants\n", report.MergedItems)
+	}
+	if report.DroppedItems > 0 {
+		fmt.Printf("Dropped %d item names that normalized to nothing\n", report.DroppedItems)
+	}
+	if len(report.SkippedNulls) > 0 {
+		tokens := make([]string, 0, len(report.SkippedNulls))
+		for token := range report.SkippedNulls {
+			tokens = append(tokens, token)
+		}
+		sort.Strings(tokens)
+		for _, token := range tokens {
+			fmt.Printf("Skipped %d %q null items\n", report.SkippedNulls[token], token)
+		}
+	}
+	if report.OversizedBaskets > 0 {
+		action := "Dropped"
+		if *truncateBaskets {
+			action = "Truncated"
+		}
+		fmt.Printf("%s %d baskets with more than %d items\n", action, report.OversizedBaskets, *maxBasket)
+	}
+
+	if *halfLife != 0 {
+		// Measure age from the latest session so historical logs still weight sensibly
+		var latest time.Time
+		for _, at := range dataset.Timestamps {
+			if at.After(latest) {
+				latest = at
+			}
+		}
+		mineOptions.Weights = algorithm.RecencyWeight(*halfLife, latest)
+		fmt.Printf("Weighting sessions with a half-life of %v before %s\n", *halfLife, latest.Format(time.RFC3339))
+	}
+
+	prunedCount, err := algorithm.CountInfrequentItems(dataset, mineOptions)
+	if err != nil {
+		log.Fatalf("Error counting infrequent items: %v", err)
+	}
+	fmt.Printf("Pruned %d items below min_support, %d items remain\n",
+		prunedCount, len(dataset.UniqueItems)-prunedCount)
+
+	// Prepare output
+	if *outDir != "" && *outDir != "." {
+		if err := os.MkdirAll(*outDir, 0755); err != nil {
+			log.Fatalf("Error creating output directory: %v", err)
+		}
+	}
+
+	itemsetsFile := filepath.Join(*outDir, "frequent_itemsets."+*itemsetsFormat)
+	rulesFile := filepath.Join(*outDir, "association_rules."+*rulesFormat)
 
-	// Find frequent itemsets
+	// Find frequent itemsets. Ctrl-C stops mining and saves the itemsets found so far;
+	// once mining is over it interrupts the run as usual.
 	fmt.Println("Finding frequent itemsets...")
 	startItemsetTime := time.Now()
-	frequentItemsets := algorithm.FindFrequentItemsets(dataset, minSupport, maxLen)
+	interrupt, stopInterrupt := signal.NotifyContext(context.Background(), os.Interrupt)
+	frequentItemsets, stats, err := algorithm.MineItemsetsPartial(interrupt, dataset, mineOptions)
+	stopInterrupt()
+	if errors.Is(err, context.Canceled) {
+		savePartialItemsets(frequentItemsets, itemsetsFile, *itemsetsFormat, csvOptions)
+	}
+	if err != nil && !errors.Is(err, algorithm.ErrNoFrequentItemsets) {
+		log.Fatalf("Error mining itemsets: %v", err)
+	}
 	itemsetTime := time.Since(startItemsetTime)
 
+	if mineOptions.Algorithm == algorithm.AlgorithmAuto && mineOptions.MemoryBudgetMB > 0 && mineOptions.Weights == nil {
+		verdict := "fits"
+		if stats.Algorithm != algorithm.AlgorithmApriori {
+			verdict = "exceeds"
+		}
+		fmt.Printf("Estimated Apriori memory %.1f MB %s budget of %d MB, using %s\n",
+			stats.EstimatedAprioriMB, verdict, mineOptions.MemoryBudgetMB, stats.Algorithm)
+	}
+
 	fmt.Printf("Found %d frequent itemsets in %v\n", len(frequentItemsets), itemsetTime)
+	if *maxItemsets > 0 && len(frequentItemsets) > *maxItemsets {
+		log.Fatalf("Found %d frequent itemsets, more than -max-itemsets %d; raise the minimum support or lower the maximum length",
+			len(frequentItemsets), *maxItemsets)
+	}
+
+	if *levelStats {
+		for i, duration := range stats.LevelDurations {
+			fmt.Printf("  Level %d: %v, %d candidates, %d frequent\n",
+				i+1, duration, stats.LevelCandidateCounts[i], stats.LevelFrequentCounts[i])
+		}
+	}
 
 	// Print frequent itemsets by length
 	lengths := make(map[int]int)
@@ -93,28 +447,285 @@ func main() {
 		fmt.Printf("  Length %d: %d itemsets\n", k, v)
 	}
 
+	ruleOptions := algorithm.RuleOptions{
+		MinConfidence:       minConfidence,
+		MaximalOnly:         *maximalRules,
+		MaxAntecedentLength: *maxAntecedent,
+		PerfectRuleMinCount: *perfectMinCount,
+		Dataset:             algorithm.SampledDataset(dataset, mineOptions), // Exact supports, over the mined transactions, for subsets a per-length threshold dropped
+	}
+
+	var filters ruleFilters
+	if *consequents != "" {
+		allowed := make(map[string]bool)
+		for _, item := range strings.Split(*consequents, ",") {
+			allowed[strings.TrimSpace(item)] = true
+		}
+		ruleOptions.AllowedConsequents = allowed
+		if *sequential {
+			// Sequential rules are not generated from the rule options
+			filters = append(filters, func(rule models.AssociationRule) bool {
+				return allowed[rule.Consequent[0]]
+			})
+		}
+	}
+	if *minLift > 0 {
+		filters = append(filters, func(rule models.AssociationRule) bool {
+			return rule.Lift >= *minLift
+		})
+	}
+	if *minLeverage > -1 {
+		filters = append(filters, func(rule models.AssociationRule) bool {
+			return rule.LeverageMetric >= *minLeverage
+		})
+	}
+	if *taxonomyFile != "" {
+		taxonomy, err := loader.LoadTaxonomyFromCSV(*taxonomyFile)
+		if err != nil {
+			log.Fatalf("Error loading taxonomy: %v", err)
+		}
+		filters = append(filters, func(rule models.AssociationRule) bool {
+			return !algorithm.WithinCategory(rule, taxonomy)
+		})
+	}
+	if *blocklistFile != "" {
+		blocked, err := loader.LoadRuleBlocklist(*blocklistFile)
+		if err != nil {
+			log.Fatalf("Error loading blocklist: %v", err)
+		}
+		blocklist := algorithm.NewRuleBlocklist(blocked)
+		filters = append(filters, func(rule models.AssociationRule) bool {
+			return !blocklist.Blocks(rule)
+		})
+	}
+	if *withItems != "" {
+		side, err := algorithm.ParseSide(*itemsSide)
+		if err != nil {
+			log.Fatalf("Invalid -items-side value: %v", err)
+		}
+		wanted := make(map[string]bool)
+		for _, item := range strings.Split(*withItems, ",") {
+			wanted[strings.TrimSpace(item)] = true
+		}
+		filters = append(filters, func(rule models.AssociationRule) bool {
+			return algorithm.MentionsItems(rule, wanted, side)
+		})
+	}
+
 	// Generate association rules
-	fmt.Println("Generating association rules...")
-	startRuleTime := time.Now()
-	rules := algorithm.GenerateAssociationRules(frequentItemsets, minConfidence)
-	ruleTime := time.Since(startRuleTime)
+	var rules []models.AssociationRule
+	if !*noRules {
+		fmt.Println("Generating association rules...")
+		startRuleTime := time.Now()
+		if *sequential {
+			rules, err = algorithm.GenerateSequentialPairRules(dataset, minSupport, minConfidence)
+			if err != nil {
+				log.Fatalf("Error generating sequential rules: %v", err)
+			}
+			rules = filters.apply(rules)
+			fmt.Printf("Generated %d sequential pair rules in %v\n", len(rules), time.Since(startRuleTime))
+			if sortMetrics != nil {
+				algorithm.SortRules(rules, sortMetrics...)
+			}
+		} else if *top > 0 {
+			// Keep only the best rules while generating, so memory stays bounded by -top
+			collector := algorithm.NewTopKCollector(*top, sortMetrics...)
+			algorithm.StreamRules(frequentItemsets, ruleOptions, func(rule models.AssociationRule) bool {
+				if filters.keep(rule) {
+					collector.Add(rule)
+				}
+				return true
+			})
+			rules = collector.Result()
+			fmt.Printf("Generated %d association rules in %v\n", collector.Seen(), time.Since(startRuleTime))
+			fmt.Printf("Kept the top %d rules by %s\n", len(rules), sortNames)
+		} else if *streamRules {
+			count, err := streamRulesToFile(frequentItemsets, ruleOptions, filters, rulesFile, *rulesFormat, csvOptions, *maxRules)
+			if errors.Is(err, errTooManyRules) {
+				os.Remove(rulesFile)
+				log.Fatalf("Generated more than -max-rules %d association rules; raise the minimum confidence or support", *maxRules)
+			}
+			if err != nil {
+				log.Fatalf("Error saving rules: %v", err)
+			}
+			fmt.Printf("Generated and saved %d association rules to %s in %v\n", count, rulesFile, time.Since(startRuleTime))
+		} else {
+			rules = filters.apply(algorithm.GenerateRules(frequentItemsets, ruleOptions))
+			fmt.Printf("Generated %d association rules in %v\n", len(rules), time.Since(startRuleTime))
+			if *scoreWeights != "" {
+				algorithm.ScoreRules(rules, weights)
+			}
+
+			if sortMetrics != nil {
+				algorithm.SortRules(rules, sortMetrics...)
+			}
+		}
 
-	fmt.Printf("Generated %d association rules in %v\n", len(rules), ruleTime)
+		if *maxRules > 0 && len(rules) > *maxRules {
+			log.Fatalf("Generated %d association rules, more than -max-rules %d; raise the minimum confidence or support",
+				len(rules), *maxRules)
+		}
+	}
 
 	// Save results
-	itemsetsFile := "frequent_itemsets.csv"
-	rulesFile := "association_rules.csv"
-
 	fmt.Println("Saving results to files...")
-	if err := output.SaveItemsetsToCSV(frequentItemsets, itemsetsFile); err != nil {
-		log.Fatalf("Error saving itemsets: %v", err)
+	if !*noItemsets {
+		savedItemsets := frequentItemsets
+		switch *itemsetType {
+		case "closed":
+			savedItemsets = algorithm.FilterClosed(frequentItemsets)
+		case "maximal":
+			savedItemsets = algorithm.FilterMaximal(frequentItemsets)
+		}
+		if err := saveItemsets(savedItemsets, itemsetsFile, *itemsetsFormat, csvOptions); err != nil {
+			log.Fatalf("Error saving itemsets: %v", err)
+		}
+		fmt.Printf("Frequent itemsets saved to %s\n", itemsetsFile)
 	}
 
-	if err := output.SaveRulesToCSV(rules, rulesFile); err != nil {
-		log.Fatalf("Error saving rules: %v", err)
+	if !*noRules && (!*streamRules || *top > 0) {
+		var err error
+		switch *rulesFormat {
+		case "jsonl":
+			err = output.SaveRulesToJSONL(rules, rulesFile)
+		case "parquet":
+			err = output.SaveRulesToParquet(rules, rulesFile)
+		default:
+			err = output.SaveRulesToCSVWithOptions(rules, rulesFile, csvOptions)
+		}
+		if err != nil {
+			log.Fatalf("Error saving rules: %v", err)
+		}
+		fmt.Printf("Association rules saved to %s\n", rulesFile)
+	}
+
+	if *saveResult != "" {
+		result := &algorithm.Result{
+			Dataset: algorithm.Summarize(dataset, inputFile),
+			Parameters: algorithm.ResultParameters{
+				MinSupport:     minSupport,
+				LengthSupport:  lengthThresholds,
+				MinConfidence:  minConfidence,
+				MaxLength:      maxLen,
+				MinLength:      *minLength,
+				Algorithm:      mineOptions.Algorithm,
+				SampleFraction: *sampleFraction,
+				Seed:           *seed,
+			},
+			Itemsets:  frequentItemsets,
+			Rules:     rules,
+			CreatedAt: time.Now(),
+		}
+		if err := result.Save(*saveResult); err != nil {
+			log.Fatalf("Error saving result: %v", err)
+		}
+		fmt.Printf("Result saved to %s\n", *saveResult)
 	}
 
-	fmt.Printf("Frequent itemsets saved to %s\n", itemsetsFile)
-	fmt.Printf("Association rules saved to %s\n", rulesFile)
 	fmt.Printf("Total execution time: %v\n", time.Since(startLoadTime))
 }
+
+// errTooManyRules stops streaming once a rule beyond the -max-rules cap is generated
+var errTooManyRules = errors.New("too many rules")
+
+// streamRulesToFile writes the rules that pass the filters to a file as they are
+// generated and returns how many were written. A maxRules above zero stops it with
+// errTooManyRules when more rules than that pass.
+func streamRulesToFile(itemsets []models.FrequentItemset, opts algorithm.RuleOptions, filters ruleFilters,
+	filePath, format string, csvOptions output.CSVOptions, maxRules int) (int, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("error creating output file: %v", err)
+	}
+	defer file.Close()
+
+	var writer output.RuleWriter
+	if format == "jsonl" {
+		writer = output.NewJSONLRuleWriter(file)
+	} else {
+		if writer, err = output.NewCSVRuleWriter(file, csvOptions); err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	var writeErr error
+	algorithm.StreamRules(itemsets, opts, func(rule models.AssociationRule) bool {
+		if !filters.keep(rule) {
+			return true
+		}
+		if maxRules > 0 && count == maxRules {
+			writeErr = errTooManyRules
+			return false
+		}
+		if writeErr = writer.Write(rule); writeErr != nil {
+			return false
+		}
+		count++
+		return true
+	})
+	if writeErr != nil {
+		return count, writeErr
+	}
+
+	return count, writer.Flush()
+}
+
+// saveItemsets writes itemsets in the given format, csv or parquet
+func saveItemsets(itemsets []models.FrequentItemset, path, format string, opts output.CSVOptions) error {
+	if format == "parquet" {
+		return output.SaveItemsetsToParquet(itemsets, path)
+	}
+	return output.SaveItemsetsToCSVWithOptions(itemsets, path, opts)
+}
+
+// savePartialItemsets writes the itemsets found before an interrupt and exits with the
+// status of a process stopped by SIGINT
+func savePartialItemsets(itemsets []models.FrequentItemset, path, format string, opts output.CSVOptions) {
+	if err := saveItemsets(itemsets, path, format, opts); err != nil {
+		log.Fatalf("Interrupted; error saving the itemsets found so far: %v", err)
+	}
+	fmt.Printf("Interrupted; saved the %d frequent itemsets found so far to %s\n", len(itemsets), path)
+	os.Exit(130)
+}
+
+// parseScoreWeights parses metric=weight pairs such as confidence=0.5,lift=0.3
+func parseScoreWeights(list string) (algorithm.ScoreWeights, error) {
+	var weights algorithm.ScoreWeights
+	for _, pair := range strings.Split(list, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return weights, fmt.Errorf("%q is not a metric=weight pair", pair)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return weights, err
+		}
+		switch strings.TrimSpace(name) {
+		case "confidence":
+			weights.Confidence = weight
+		case "lift":
+			weights.Lift = weight
+		case "support":
+			weights.Support = weight
+		case "revenue":
+			weights.Revenue = weight
+		default:
+			return weights, fmt.Errorf("unknown metric %q, must be confidence, lift, support or revenue", name)
+		}
+	}
+	return weights, nil
+}
+
+// parseFloatList parses a comma-separated list of numbers
+func parseFloatList(list string) ([]float64, error) {
+	values := make([]float64, 0)
+	for _, field := range strings.Split(list, ",") {
+		value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
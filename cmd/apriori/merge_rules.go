@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/loader"
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+	"github.com/RiceaRaul/AprioriGO/internal/output"
+)
+
+// runMergeRules combines several rules CSV files into one, deduplicating rules
+func runMergeRules(arguments []string) {
+	fs := flag.NewFlagSet("merge-rules", flag.ExitOnError)
+	aggName := fs.String("agg", "max", "How to combine the metrics of duplicate rules: max, min, mean or first")
+	fs.Usage = func() {
+		fmt.Println("Usage: apriori merge-rules [options] <out_csv> <in_csv> [in_csv...]")
+		fmt.Println("  - out_csv: Path to write the merged rules to")
+		fmt.Println("  - in_csv: Rules CSV files written by apriori")
+		fmt.Println("Options:")
+		fs.SetOutput(os.Stdout)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(arguments) // ExitOnError exits on invalid flags
+
+	args := fs.Args()
+	if len(args) < 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	agg, err := algorithm.ParseAggregation(*aggName)
+	if err != nil {
+		log.Fatalf("Invalid -agg value: %v", err)
+	}
+
+	outputFile := args[0]
+	ruleSets := make([][]models.AssociationRule, 0, len(args)-1)
+	total := 0
+	for _, inputFile := range args[1:] {
+		rules, err := loader.LoadRulesFromCSV(inputFile)
+		if err != nil {
+			log.Fatalf("Error loading rules from %s: %v", inputFile, err)
+		}
+		ruleSets = append(ruleSets, rules)
+		total += len(rules)
+	}
+
+	merged := algorithm.MergeRules(ruleSets, agg)
+	if err := output.SaveRulesToCSV(merged, outputFile); err != nil {
+		log.Fatalf("Error saving rules: %v", err)
+	}
+
+	fmt.Printf("Merged %d rules from %d files into %d unique rules in %s\n",
+		total, len(ruleSets), len(merged), outputFile)
+}
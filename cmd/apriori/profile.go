@@ -0,0 +1,44 @@
+package main
+
+import (
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiling starts a CPU profile when cpuPath is set and returns a function that
+// stops it and, when memPath is set, writes a heap profile
+func startProfiling(cpuPath, memPath string) func() {
+	var cpuFile *os.File
+	if cpuPath != "" {
+		var err error
+		cpuFile, err = os.Create(cpuPath)
+		if err != nil {
+			log.Fatalf("Could not create CPU profile: %v", err)
+		}
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			log.Fatalf("Could not start CPU profile: %v", err)
+		}
+	}
+
+	return func() {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			cpuFile.Close()
+		}
+
+		if memPath != "" {
+			memFile, err := os.Create(memPath)
+			if err != nil {
+				log.Fatalf("Could not create memory profile: %v", err)
+			}
+			defer memFile.Close()
+
+			runtime.GC() // Run garbage collection so the profile shows live memory
+			if err := pprof.WriteHeapProfile(memFile); err != nil {
+				log.Fatalf("Could not write memory profile: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// querySession holds a loaded result and the lookups its REPL commands use
+type querySession struct {
+	result   *algorithm.Result
+	supports *algorithm.SupportResolver
+}
+
+// runQuery loads a result saved with -save-result and answers questions about it
+// interactively
+func runQuery(arguments []string) {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println("Usage: apriori query <result_file>")
+		fmt.Println("  - result_file: A result saved by apriori -save-result")
+		fmt.Println("Reads commands from standard input; type help for the list.")
+	}
+	_ = fs.Parse(arguments) // ExitOnError exits on invalid flags
+
+	args := fs.Args()
+	if len(args) < 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	result, err := algorithm.LoadResult(args[0])
+	if err != nil {
+		log.Fatalf("Error loading result: %v", err)
+	}
+
+	session := &querySession{result: result, supports: algorithm.NewSupportResolver(result.Itemsets, nil)}
+	fmt.Printf("Loaded %d itemsets and %d rules mined from %d transactions (min_support=%.4f, min_confidence=%.4f)\n",
+		len(result.Itemsets), len(result.Rules), result.Dataset.TransactionCount,
+		result.Parameters.MinSupport, result.Parameters.MinConfidence)
+	session.repl(os.Stdin, os.Stdout)
+}
+
+// repl reads one command per line until the input ends or the user quits
+func (s *querySession) repl(in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return
+		}
+
+		command, rest, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		rest = strings.TrimSpace(rest)
+		switch command {
+		case "":
+		case "quit", "exit":
+			return
+		case "help":
+			fmt.Fprintln(out, "  support <items>     Support of an itemset, e.g. support milk,bread")
+			fmt.Fprintln(out, "  rules-for <items>   Rules mentioning any of the items, most confident first")
+			fmt.Fprintln(out, "  top-lift <n>        The n rules with the highest lift")
+			fmt.Fprintln(out, "  recommend <items>   Items to suggest for a basket holding the items")
+			fmt.Fprintln(out, "  quit                Leave the query session")
+		case "support":
+			s.support(out, parseItems(rest))
+		case "rules-for":
+			rules := algorithm.FilterRulesByItems(s.result.Rules, parseItems(rest), algorithm.SideEither)
+			algorithm.SortRules(rules, algorithm.MetricConfidence)
+			printRules(out, rules)
+		case "top-lift":
+			n, err := strconv.Atoi(rest)
+			if err != nil || n <= 0 {
+				fmt.Fprintln(out, "  top-lift needs a positive number of rules, e.g. top-lift 10")
+				continue
+			}
+			printRules(out, algorithm.TopKRules(s.result.Rules, n, algorithm.MetricLift))
+		case "recommend":
+			s.recommend(out, parseItems(rest))
+		default:
+			fmt.Fprintf(out, "  unknown command %q; type help for the list\n", command)
+		}
+	}
+}
+
+// support prints the support of an itemset, if it was frequent
+func (s *querySession) support(out io.Writer, items []string) {
+	if len(items) == 0 {
+		fmt.Fprintln(out, "  support needs a comma-separated list of items")
+		return
+	}
+	support, ok := s.supports.Support(items)
+	if !ok {
+		fmt.Fprintf(out, "  {%s} is not frequent: its support is below %.4f\n", strings.Join(items, ","), s.result.Parameters.MinSupport)
+		return
+	}
+	count, _ := s.supports.Count(items)
+	fmt.Fprintf(out, "  {%s}: support=%.4f (%d transactions)\n", strings.Join(items, ","), support, count)
+}
+
+// recommend prints the items the rules suggest for a basket
+func (s *querySession) recommend(out io.Writer, basket []string) {
+	recommendations := algorithm.Recommend(s.result.Rules, basket, 10)
+	if len(recommendations) == 0 {
+		fmt.Fprintln(out, "  no rule applies to this basket")
+		return
+	}
+	for _, recommendation := range recommendations {
+		fmt.Fprintf(out, "  %-20s confidence=%.4f lift=%.4f  from %s\n", recommendation.Item,
+			recommendation.Confidence, recommendation.Lift, formatRule(recommendation.Rule))
+	}
+}
+
+// printRules prints one rule per line with its main metrics
+func printRules(out io.Writer, rules []models.AssociationRule) {
+	if len(rules) == 0 {
+		fmt.Fprintln(out, "  no matching rules")
+		return
+	}
+	for _, rule := range rules {
+		fmt.Fprintf(out, "  %s  support=%.4f confidence=%.4f lift=%.4f\n", formatRule(rule), rule.Support, rule.Confidence, rule.Lift)
+	}
+}
+
+// formatRule renders a rule as {antecedent} => {consequent}
+func formatRule(rule models.AssociationRule) string {
+	return fmt.Sprintf("{%s} => {%s}", strings.Join(rule.Antecedent, ","), strings.Join(rule.Consequent, ","))
+}
+
+// parseItems splits a comma-separated item list, trimming spaces around each item
+func parseItems(list string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/loader"
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+	"github.com/RiceaRaul/AprioriGO/internal/output"
+)
+
+// runRules generates rules from a saved itemsets file, so the expensive mining step
+// can be reused at several confidence thresholds
+func runRules(arguments []string) {
+	fs := flag.NewFlagSet("rules", flag.ExitOnError)
+	outFile := fs.String("out", "association_rules.csv", "Path to write the rules CSV to")
+	dataFile := fs.String("data", "", "Basket,item CSV the itemsets were mined from, to count supports missing from the itemsets file")
+	maxAntecedent := fs.Int("max-antecedent", 0, "Maximum number of items in a rule antecedent (0 for no limit)")
+	fs.Usage = func() {
+		fmt.Println("Usage: apriori rules [options] <itemsets_csv> [min_confidence]")
+		fmt.Println("  - itemsets_csv: A frequent itemsets CSV written by apriori")
+		fmt.Println("  - min_confidence: Minimum confidence threshold (default: 0.2)")
+		fmt.Println("Options:")
+		fs.SetOutput(os.Stdout)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(arguments) // ExitOnError exits on invalid flags
+
+	args := fs.Args()
+	if len(args) < 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	minConfidence := 0.2
+	if len(args) > 1 {
+		var err error
+		if minConfidence, err = strconv.ParseFloat(args[1], 64); err != nil {
+			log.Fatalf("Invalid min_confidence value: %v", err)
+		}
+	}
+
+	itemsets, err := loader.LoadItemsetsFromCSV(args[0])
+	if err != nil {
+		log.Fatalf("Error loading itemsets: %v", err)
+	}
+	fmt.Printf("Loaded %d frequent itemsets\n", len(itemsets))
+
+	var dataset *models.Dataset
+	if *dataFile != "" {
+		if dataset, err = loader.LoadFromCSV(*dataFile); err != nil {
+			log.Fatalf("Error loading dataset: %v", err)
+		}
+	} else if missing := algorithm.MissingSubsets(itemsets); missing > 0 {
+		fmt.Printf("Warning: %d subsets of the itemsets are not in the file, e.g. because only closed or maximal itemsets were saved; "+
+			"rules needing their support are skipped unless -data is given\n", missing)
+	}
+
+	startTime := time.Now()
+	rules := algorithm.GenerateRules(itemsets, algorithm.RuleOptions{
+		MinConfidence:       minConfidence,
+		MaxAntecedentLength: *maxAntecedent,
+		Dataset:             dataset,
+	})
+	fmt.Printf("Generated %d rules with min_confidence=%.4f in %v\n", len(rules), minConfidence, time.Since(startTime))
+
+	if err := output.SaveRulesToCSV(rules, *outFile); err != nil {
+		log.Fatalf("Error saving rules: %v", err)
+	}
+	fmt.Printf("Rules saved to %s\n", *outFile)
+}
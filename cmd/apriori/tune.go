@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/loader"
+)
+
+// runTune searches for the minimum support that yields about a target number of
+// frequent itemsets
+func runTune(arguments []string) {
+	fs := flag.NewFlagSet("tune", flag.ExitOnError)
+	targetItemsets := fs.Int("target-itemsets", 500, "Number of frequent itemsets to aim for")
+	tolerance := fs.Float64("tolerance", 0.1, "Accept a support whose itemset count is within this fraction of the target")
+	maxLen := fs.Int("max-length", 5, "Maximum itemset length to mine at each step")
+	fs.Usage = func() {
+		fmt.Println("Usage: apriori tune [options] <csv_file>")
+		fmt.Println("  - csv_file: Path to the CSV file with columns for Basket and Item")
+		fmt.Println("Options:")
+		fs.SetOutput(os.Stdout)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(arguments) // ExitOnError exits on invalid flags
+
+	args := fs.Args()
+	if len(args) < 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	dataset, err := loader.LoadFromCSV(args[0])
+	if err != nil {
+		log.Fatalf("Error loading dataset: %v", err)
+	}
+	fmt.Printf("Found %d transactions and %d unique items\n",
+		len(dataset.Transactions), len(dataset.UniqueItems))
+
+	result, err := algorithm.AutoTuneSupport(dataset, algorithm.MineOptions{MaxLength: *maxLen}, *targetItemsets, *tolerance)
+	if err != nil {
+		log.Fatalf("Error tuning support: %v", err)
+	}
+
+	fmt.Printf("%-8s %-15s %-15s\n", "Step", "Support", "Itemsets")
+	for i, step := range result.Steps {
+		fmt.Printf("%-8d %-15.6f %-15d\n", i+1, step.MinSupport, step.Itemsets)
+	}
+	fmt.Printf("Chosen min_support=%.6f gives %d frequent itemsets (target %d)\n",
+		result.MinSupport, len(result.Itemsets), *targetItemsets)
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -17,6 +21,7 @@ import (
 )
 
 type BenchmarkResult struct {
+	Algorithm     algorithm.Algorithm
 	MinSupport    float64
 	MinConfidence float64
 	MaxLength     int
@@ -27,18 +32,63 @@ type BenchmarkResult struct {
 	ItemsetCount  int
 	RuleCount     int
 	Memory        uint64 // in bytes
+
+	// Times above are means over Iterations runs; these are their standard deviations
+	Iterations    int
+	ItemsetStdDev time.Duration
+	RuleStdDev    time.Duration
+	TotalStdDev   time.Duration
+
+	// TimedOut marks a combination whose mining exceeded -run-timeout; its times and
+	// counts are those of the aborted run
+	TimedOut bool
+}
+
+// benchmarkConfig is one parameter combination of the benchmark grid
+type benchmarkConfig struct {
+	Algorithm     algorithm.Algorithm
+	MinSupport    float64
+	MinConfidence float64
+	MaxLength     int
 }
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: benchmark <csv_file> [output_file]")
+	// Parse command line flags
+	algorithmList := flag.String("algorithms", "apriori", "Comma-separated algorithms to compare (apriori,fpgrowth,eclat)")
+	sweep := flag.String("sweep", "", "Vary only this parameter (support, confidence or length), holding the others at their -fixed values")
+	fixedSupport := flag.Float64("fixed-support", 0.01, "min_support used while sweeping another parameter")
+	fixedConfidence := flag.Float64("fixed-confidence", 0.3, "min_confidence used while sweeping another parameter")
+	fixedLength := flag.Int("fixed-length", 4, "max_length used while sweeping another parameter")
+	runTimeout := flag.Duration("run-timeout", 0, "Abort a combination whose itemset mining takes longer than this, e.g. 2m, and record it as timed out (0 for no limit)")
+	iterations := flag.Int("iterations", 1, "Timed runs per combination; above 1, an untimed warmup run comes first")
+
+	flag.Usage = func() {
+		fmt.Println("Usage: benchmark [options] <csv_file> [output_file]")
 		fmt.Println("  - csv_file: Path to the CSV file with transaction data")
 		fmt.Println("  - output_file: Optional path to save benchmark results (default: benchmark_results.csv)")
+		fmt.Println("Options:")
+		flag.CommandLine.SetOutput(os.Stdout)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		flag.Usage()
 		os.Exit(1)
 	}
 
+	algorithms, err := parseAlgorithms(*algorithmList)
+	if err != nil {
+		log.Fatalf("Invalid -algorithms value: %v", err)
+	}
+
+	if *iterations < 1 {
+		log.Fatalf("Invalid -iterations value %d: must be at least 1", *iterations)
+	}
+
 	// Get input file
-	inputFile := os.Args[1]
+	inputFile := args[0]
 
 	// Check if input file exists
 	if _, err := os.Stat(inputFile); os.IsNotExist(err) {
@@ -47,8 +97,8 @@ func main() {
 
 	// Set output file
 	outputFile := "benchmark_results.csv"
-	if len(os.Args) > 2 {
-		outputFile = os.Args[2]
+	if len(args) > 1 {
+		outputFile = args[1]
 	}
 
 	// Create CPU profile if needed (uncomment to enable)
@@ -80,39 +130,76 @@ func main() {
 		len(dataset.Transactions), len(dataset.UniqueItems))
 
 	// Format for output
-	fmt.Printf("%-10s %-10s %-10s %-15s %-15s %-15s %-10s %-10s\n",
-		"Support", "Confidence", "MaxLen", "Itemset Time", "Rule Time", "Total Time", "Itemsets", "Rules")
-	fmt.Println(strings.Repeat("-", 100))
+	fmt.Printf("%-10s %-10s %-10s %-10s %-15s %-15s %-15s %-10s %-10s\n",
+		"Algorithm", "Support", "Confidence", "MaxLen", "Itemset Time", "Rule Time", "Total Time", "Itemsets", "Rules")
+	fmt.Println(strings.Repeat("-", 111))
+
+	// A sweep varies one parameter and pins the others, so every combination is
+	// run as asked; the full grid skips combinations that are likely to be too
+	// slow or memory-intensive
+	skipSlow := true
+	switch *sweep {
+	case "":
+	case "support":
+		minConfidences, maxLengths, skipSlow = []float64{*fixedConfidence}, []int{*fixedLength}, false
+	case "confidence":
+		minSupports, maxLengths, skipSlow = []float64{*fixedSupport}, []int{*fixedLength}, false
+	case "length":
+		minSupports, minConfidences, skipSlow = []float64{*fixedSupport}, []float64{*fixedConfidence}, false
+	default:
+		log.Fatalf("Invalid -sweep value %q: must be support, confidence or length", *sweep)
+	}
 
-	// Run the benchmark for each parameter combination
+	// Build the parameter grid
+	configs := make([]benchmarkConfig, 0)
 	for _, minSupport := range minSupports {
 		for _, minConfidence := range minConfidences {
 			for _, maxLength := range maxLengths {
-				// Skip combinations that are likely to be too slow or memory-intensive
-				if minSupport < 0.005 && maxLength > 3 {
+				if skipSlow && minSupport < 0.005 && maxLength > 3 {
 					continue
 				}
+				for _, algo := range algorithms {
+					configs = append(configs, benchmarkConfig{algo, minSupport, minConfidence, maxLength})
+				}
+			}
+		}
+	}
 
-				fmt.Printf("Testing: support=%.4f, confidence=%.4f, maxLength=%d\n",
-					minSupport, minConfidence, maxLength)
+	// Run the benchmark for each parameter combination
+	startBenchmark := time.Now()
+	for i, config := range configs {
+		fmt.Printf("Testing: algorithm=%s, support=%.4f, confidence=%.4f, maxLength=%d\n",
+			config.Algorithm, config.MinSupport, config.MinConfidence, config.MaxLength)
+
+		// Run the benchmark
+		result := runBenchmark(dataset, config, *iterations, *runTimeout)
+		results = append(results, result)
+		if result.TimedOut {
+			fmt.Printf("  timed out after %s\n", formatDuration(*runTimeout))
+		}
 
-				// Run the benchmark
-				result := runBenchmark(dataset, minSupport, minConfidence, maxLength)
-				results = append(results, result)
+		// Format output
+		fmt.Printf("%-10s %-10.4f %-10.4f %-10d %-15s %-15s %-15s %-10d %-10d\n",
+			config.Algorithm, config.MinSupport, config.MinConfidence, config.MaxLength,
+			formatDuration(result.ItemsetTime),
+			formatDuration(result.RuleTime),
+			formatDuration(result.TotalTime),
+			result.ItemsetCount,
+			result.RuleCount)
+		if result.Iterations > 1 {
+			fmt.Printf("  stddev over %d runs: itemsets %s, rules %s, total %s\n", result.Iterations,
+				formatDuration(result.ItemsetStdDev), formatDuration(result.RuleStdDev), formatDuration(result.TotalStdDev))
+		}
 
-				// Format output
-				fmt.Printf("%-10.4f %-10.4f %-10d %-15s %-15s %-15s %-10d %-10d\n",
-					minSupport, minConfidence, maxLength,
-					formatDuration(result.ItemsetTime),
-					formatDuration(result.RuleTime),
-					formatDuration(result.TotalTime),
-					result.ItemsetCount,
-					result.RuleCount)
+		// Estimate the remaining time from the average time per run so far
+		done := i + 1
+		average := time.Since(startBenchmark) / time.Duration(done)
+		remaining := average * time.Duration(len(configs)-done)
+		fmt.Printf("Progress: %s %d/%d combinations, est. remaining %s\n",
+			progressBar(done, len(configs), 30), done, len(configs), formatDuration(remaining))
 
-				// Force garbage collection to prevent memory buildup
-				runtime.GC()
-			}
-		}
+		// Force garbage collection to prevent memory buildup
+		runtime.GC()
 	}
 
 	// Save results to CSV
@@ -134,21 +221,97 @@ func main() {
 	}
 }
 
-func runBenchmark(dataset *models.Dataset, minSupport, minConfidence float64, maxLength int) BenchmarkResult {
+// runBenchmark times a configuration over several iterations, preceded by an untimed
+// warmup run when more than one iteration is requested. The first run that times out
+// ends the configuration and is returned as its result.
+func runBenchmark(dataset *models.Dataset, config benchmarkConfig, iterations int, timeout time.Duration) BenchmarkResult {
+	if iterations > 1 {
+		warmup := runOnce(dataset, config, timeout)
+		runtime.GC()
+		if warmup.TimedOut {
+			return warmup
+		}
+	}
+
+	runs := make([]BenchmarkResult, iterations)
+	for i := range runs {
+		runs[i] = runOnce(dataset, config, timeout)
+		runtime.GC()
+		if runs[i].TimedOut {
+			return runs[i]
+		}
+	}
+
+	result := runs[len(runs)-1]
+	result.Iterations = iterations
+	result.ItemsetTime, result.ItemsetStdDev = meanStdDev(runs, func(r BenchmarkResult) time.Duration { return r.ItemsetTime })
+	result.RuleTime, result.RuleStdDev = meanStdDev(runs, func(r BenchmarkResult) time.Duration { return r.RuleTime })
+	result.TotalTime, result.TotalStdDev = meanStdDev(runs, func(r BenchmarkResult) time.Duration { return r.TotalTime })
+
+	return result
+}
+
+// meanStdDev returns the mean and sample standard deviation of a duration across runs
+func meanStdDev(runs []BenchmarkResult, value func(BenchmarkResult) time.Duration) (time.Duration, time.Duration) {
+	var sum float64
+	for _, run := range runs {
+		sum += float64(value(run))
+	}
+	mean := sum / float64(len(runs))
+
+	if len(runs) < 2 {
+		return time.Duration(mean), 0
+	}
+
+	var squares float64
+	for _, run := range runs {
+		diff := float64(value(run)) - mean
+		squares += diff * diff
+	}
+	return time.Duration(mean), time.Duration(math.Sqrt(squares / float64(len(runs)-1)))
+}
+
+func runOnce(dataset *models.Dataset, config benchmarkConfig, timeout time.Duration) BenchmarkResult {
 	startTotal := time.Now()
 	var itemsetCount, ruleCount int
 	var itemsetTime, ruleTime time.Duration
 	var memStats runtime.MemStats
 
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// Find frequent itemsets
 	startItemset := time.Now()
-	frequentItemsets := algorithm.FindFrequentItemsets(dataset, minSupport, maxLength)
+	frequentItemsets, err := algorithm.MineItemsetsContext(ctx, dataset, algorithm.MineOptions{
+		MinSupport: config.MinSupport,
+		MaxLength:  config.MaxLength,
+		Algorithm:  config.Algorithm,
+	})
 	itemsetTime = time.Since(startItemset)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return BenchmarkResult{
+			Algorithm:     config.Algorithm,
+			MinSupport:    config.MinSupport,
+			MinConfidence: config.MinConfidence,
+			MaxLength:     config.MaxLength,
+			ItemsetTime:   itemsetTime,
+			TotalTime:     time.Since(startTotal),
+			Iterations:    1,
+			TimedOut:      true,
+		}
+	}
+	if err != nil && !errors.Is(err, algorithm.ErrNoFrequentItemsets) {
+		log.Fatalf("Error mining itemsets: %v", err)
+	}
 	itemsetCount = len(frequentItemsets)
 
 	// Generate association rules
 	startRule := time.Now()
-	rules := algorithm.GenerateAssociationRules(frequentItemsets, minConfidence)
+	rules := algorithm.GenerateAssociationRules(frequentItemsets, config.MinConfidence)
 	ruleTime = time.Since(startRule)
 	ruleCount = len(rules)
 
@@ -156,9 +319,10 @@ func runBenchmark(dataset *models.Dataset, minSupport, minConfidence float64, ma
 	runtime.ReadMemStats(&memStats)
 
 	return BenchmarkResult{
-		MinSupport:    minSupport,
-		MinConfidence: minConfidence,
-		MaxLength:     maxLength,
+		Algorithm:     config.Algorithm,
+		MinSupport:    config.MinSupport,
+		MinConfidence: config.MinConfidence,
+		MaxLength:     config.MaxLength,
 		LoadTime:      0, // Dataset already loaded
 		ItemsetTime:   itemsetTime,
 		RuleTime:      ruleTime,
@@ -199,6 +363,12 @@ func saveResultsToCSV(results []BenchmarkResult, outputFile string) error {
 		"itemset_count",
 		"rule_count",
 		"memory_usage_mb",
+		"algorithm",
+		"iterations",
+		"itemset_time_std_ms",
+		"rule_time_std_ms",
+		"total_time_std_ms",
+		"timed_out",
 	}
 	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("error writing header: %v", err)
@@ -216,6 +386,12 @@ func saveResultsToCSV(results []BenchmarkResult, outputFile string) error {
 			fmt.Sprintf("%d", result.ItemsetCount),
 			fmt.Sprintf("%d", result.RuleCount),
 			fmt.Sprintf("%.2f", float64(result.Memory)/(1024*1024)), // Convert to MB
+			string(result.Algorithm),
+			fmt.Sprintf("%d", result.Iterations),
+			fmt.Sprintf("%.3f", float64(result.ItemsetStdDev)/float64(time.Millisecond)),
+			fmt.Sprintf("%.3f", float64(result.RuleStdDev)/float64(time.Millisecond)),
+			fmt.Sprintf("%.3f", float64(result.TotalStdDev)/float64(time.Millisecond)),
+			fmt.Sprintf("%t", result.TimedOut),
 		}
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("error writing result: %v", err)
@@ -234,3 +410,32 @@ func formatDuration(d time.Duration) string {
 		return fmt.Sprintf("%.1fm %.1fs", d.Minutes(), d.Seconds()-float64(int(d.Minutes()))*60)
 	}
 }
+
+func progressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", width-filled) + "]"
+}
+
+func parseAlgorithms(list string) ([]algorithm.Algorithm, error) {
+	algorithms := make([]algorithm.Algorithm, 0)
+	for _, name := range strings.Split(list, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		algo, err := algorithm.ParseAlgorithm(name)
+		if err != nil {
+			return nil, err
+		}
+		if algo == algorithm.AlgorithmAuto {
+			return nil, fmt.Errorf("auto is not a concrete algorithm to benchmark")
+		}
+		algorithms = append(algorithms, algo)
+	}
+	if len(algorithms) == 0 {
+		return nil, fmt.Errorf("no algorithms given")
+	}
+	return algorithms, nil
+}
@@ -1,3 +1,5 @@
 module github.com/RiceaRaul/AprioriGO
 
 go 1.23
+
+require golang.org/x/text v0.21.0
@@ -0,0 +1,184 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// PairAffinity is the symmetric association between two items
+type PairAffinity struct {
+	A, B    string // Item names, A < B
+	Count   int    // Transactions containing both items
+	Support float64
+	Lift    float64 // P(A and B) / (P(A) * P(B))
+	Jaccard float64 // |A and B| / |A or B|
+}
+
+// PairAffinities computes lift and Jaccard similarity for every frequent 2-itemset.
+// Single-item counts come from the itemsets when present and from the dataset otherwise.
+func PairAffinities(itemsets []models.FrequentItemset, dataset *models.Dataset) []PairAffinity {
+	transactionCount := len(dataset.Transactions)
+	if transactionCount == 0 {
+		return []PairAffinity{}
+	}
+
+	singles := make(map[string]int)
+	for _, itemset := range itemsets {
+		if len(itemset.Items) == 1 {
+			singles[itemset.Items[0]] = itemset.Count
+		}
+	}
+	count := func(item string) int {
+		if n, ok := singles[item]; ok {
+			return n
+		}
+		n := SupportCount(dataset, []string{item})
+		singles[item] = n
+		return n
+	}
+
+	affinities := make([]PairAffinity, 0)
+	for _, itemset := range itemsets {
+		if len(itemset.Items) != 2 {
+			continue
+		}
+
+		pair := sortedCopy(itemset.Items)
+		both := itemset.Count
+		countA, countB := count(pair[0]), count(pair[1])
+		if countA == 0 || countB == 0 {
+			continue
+		}
+
+		total := float64(transactionCount)
+		affinities = append(affinities, PairAffinity{
+			A:       pair[0],
+			B:       pair[1],
+			Count:   both,
+			Support: float64(both) / total,
+			Lift:    (float64(both) / total) / ((float64(countA) / total) * (float64(countB) / total)),
+			Jaccard: float64(both) / float64(countA+countB-both),
+		})
+	}
+
+	return affinities
+}
+
+// PairExpectation compares how often two items occur together with how often they
+// would if they were independent
+type PairExpectation struct {
+	A, B       string  // Item names, A < B
+	Observed   float64 // Support of the pair
+	Expected   float64 // Product of the single-item supports
+	Ratio      float64 // Observed / Expected, the itemset-level lift
+	Difference float64 // Observed - Expected, the itemset-level leverage
+}
+
+// PairExpectations computes observed against expected support for every frequent
+// 2-itemset. Single-item supports come from the itemsets and, when dataset is not
+// nil, from the dataset for items that were not mined. Pairs whose item supports are
+// unknown are skipped.
+func PairExpectations(itemsets []models.FrequentItemset, dataset *models.Dataset) []PairExpectation {
+	supports := NewSupportResolver(itemsets, dataset)
+
+	expectations := make([]PairExpectation, 0)
+	for _, itemset := range itemsets {
+		if len(itemset.Items) != 2 || itemset.Items[0] == itemset.Items[1] {
+			continue
+		}
+
+		pair := sortedCopy(itemset.Items)
+		supportA, okA := supports.Support(pair[:1])
+		supportB, okB := supports.Support(pair[1:])
+		if !okA || !okB || supportA == 0 || supportB == 0 {
+			continue
+		}
+
+		expected := supportA * supportB
+		expectations = append(expectations, PairExpectation{
+			A:          pair[0],
+			B:          pair[1],
+			Observed:   itemset.Support,
+			Expected:   expected,
+			Ratio:      itemset.Support / expected,
+			Difference: itemset.Support - expected,
+		})
+	}
+
+	return expectations
+}
+
+// Measure names a pairwise item measure for an affinity matrix
+type Measure string
+
+const (
+	MeasureCount   Measure = "count"   // Transactions containing both items
+	MeasureSupport Measure = "support" // Fraction of transactions containing both items
+	MeasureLift    Measure = "lift"    // P(A and B) / (P(A) * P(B))
+	MeasureJaccard Measure = "jaccard" // |A and B| / |A or B|
+)
+
+// ParseMeasure converts a measure name to a Measure
+func ParseMeasure(name string) (Measure, error) {
+	switch measure := Measure(name); measure {
+	case MeasureCount, MeasureSupport, MeasureLift, MeasureJaccard:
+		return measure, nil
+	default:
+		return "", fmt.Errorf("%w: unknown measure %q, must be count, support, lift or jaccard", ErrInvalidOption, name)
+	}
+}
+
+// AffinityMatrix computes a measure for every pair of the given items, or of all the
+// dataset's items when items is empty, as a symmetric matrix in item order. The
+// diagonal pairs each item with itself: its count or support, a Jaccard of 1, and NaN
+// for lift, which would only be the inverse of the item's support. Lift and Jaccard
+// are NaN for items that never occur.
+func AffinityMatrix(dataset *models.Dataset, items []string, measure Measure) ([][]float64, error) {
+	if _, err := ParseMeasure(string(measure)); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		items = dataset.UniqueItems
+	}
+
+	index := dataset.InvertedIndex()
+	total := float64(len(dataset.Transactions))
+	matrix := make([][]float64, len(items))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(items))
+	}
+
+	for i, a := range items {
+		for j := i; j < len(items); j++ {
+			b := items[j]
+			countA, countB := len(index[a]), len(index[b])
+			both := countA
+			if i != j {
+				both = len(intersectSorted(index[a], index[b]))
+			}
+
+			value := math.NaN()
+			switch measure {
+			case MeasureCount:
+				value = float64(both)
+			case MeasureSupport:
+				if total > 0 {
+					value = float64(both) / total
+				}
+			case MeasureLift:
+				if i != j && countA > 0 && countB > 0 {
+					value = float64(both) * total / (float64(countA) * float64(countB))
+				}
+			case MeasureJaccard:
+				if union := countA + countB - both; union > 0 {
+					value = float64(both) / float64(union)
+				}
+			}
+			matrix[i][j], matrix[j][i] = value, value
+		}
+	}
+
+	return matrix, nil
+}
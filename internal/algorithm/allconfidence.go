@@ -0,0 +1,45 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// filterAllConfidence keeps the itemsets whose all-confidence, their support divided by
+// the highest support of any of their items, is at least minAllConfidence. Single items
+// always have an all-confidence of 1. All-confidence never grows when an item is added,
+// so the subsets of a kept itemset are kept too.
+func filterAllConfidence(itemsets []models.FrequentItemset, minAllConfidence float64, itemSupports map[string]float64) []models.FrequentItemset {
+	filtered := make([]models.FrequentItemset, 0, len(itemsets))
+	for _, itemset := range itemsets {
+		highest := 0.0
+		for _, item := range itemset.Items {
+			highest = max(highest, itemSupports[item])
+		}
+		if highest > 0 && itemset.Support/highest >= minAllConfidence {
+			filtered = append(filtered, itemset)
+		}
+	}
+	return filtered
+}
+
+// itemSupports returns the support of every item in the dataset, as a share of the
+// total weight when weights is not nil
+func itemSupports(dataset *models.Dataset, weights []float64) map[string]float64 {
+	supports := make(map[string]float64, len(dataset.UniqueItems))
+	if weights == nil {
+		transactionCount := float64(len(dataset.Transactions))
+		for item, count := range itemCounts(dataset) {
+			supports[item] = float64(count) / transactionCount
+		}
+		return supports
+	}
+
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	for item, tids := range dataset.InvertedIndex() {
+		supports[item] = weightedSupport(tids, weights, totalWeight)
+	}
+	return supports
+}
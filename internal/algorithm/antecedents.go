@@ -0,0 +1,89 @@
+package algorithm
+
+import (
+	"math/bits"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Evaluation states of a candidate antecedent; the zero value marks one not yet
+// evaluated
+const (
+	antecedentPruned  = iota + 1 // A superset failed, so this one must fail too
+	antecedentPasses             // Meets the confidence threshold
+	antecedentUnknown            // Support missing, zero or not looked up, so its subsets cannot be ruled out
+	antecedentFails              // Below the confidence threshold
+)
+
+// confidentAntecedents returns the proper subsets of an itemset, of at most maxSize
+// items, whose rules reach minConfidence. Confidence only falls as items move from
+// the antecedent to the consequent, so antecedents are evaluated largest first and
+// the subsets of a failing antecedent are skipped without being looked up, as are
+// antecedents longer than maxSize. The result follows the order of the subset
+// bitmasks, matching exhaustive enumeration.
+func confidentAntecedents(itemset models.FrequentItemset, supports *SupportResolver, minConfidence float64, maxSize int) [][]string {
+	items := itemset.Items
+	n := len(items)
+	full := 1<<uint(n) - 1
+	state := make([]int8, full)
+
+	// Every superset of a mask is numerically larger, so a descending scan
+	// settles all supersets before their subsets
+	for mask := full - 1; mask >= 1; mask-- {
+		pruned := false
+		for j := 0; j < n; j++ {
+			superset := mask | 1<<uint(j)
+			if superset == mask || superset == full {
+				continue
+			}
+			if s := state[superset]; s == antecedentFails || s == antecedentPruned {
+				pruned = true
+				break
+			}
+		}
+		if pruned {
+			state[mask] = antecedentPruned
+			continue
+		}
+
+		if bits.OnesCount(uint(mask)) > maxSize {
+			// Too long to be reported, so skip the lookup; its subsets are still
+			// evaluated on their own
+			state[mask] = antecedentUnknown
+			continue
+		}
+
+		support, exists := supports.Support(maskItems(items, mask))
+		switch {
+		case !exists:
+			state[mask] = antecedentUnknown // Should not happen with proper subsets
+		case support == 0:
+			// Only possible with a zero support threshold or loaded itemsets; the
+			// confidence is undefined, and a subset may still occur
+			state[mask] = antecedentUnknown
+		case itemset.Support/support >= minConfidence:
+			state[mask] = antecedentPasses
+		default:
+			state[mask] = antecedentFails
+		}
+	}
+
+	antecedents := make([][]string, 0)
+	for mask := 1; mask < full; mask++ {
+		if state[mask] == antecedentPasses {
+			antecedents = append(antecedents, maskItems(items, mask))
+		}
+	}
+	return antecedents
+}
+
+// maskItems returns the items selected by the bits of mask, in their original order
+func maskItems(items []string, mask int) []string {
+	subset := make([]string, 0, bits.OnesCount(uint(mask)))
+	for j := range items {
+		if mask&(1<<uint(j)) != 0 {
+			subset = append(subset, items[j])
+		}
+	}
+	return subset
+}
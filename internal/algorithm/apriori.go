@@ -1,78 +1,142 @@
 package algorithm
 
 import (
-	"math"
+	"context"
 	"sort"
-	"strings"
+	"time"
 
 	"github.com/RiceaRaul/AprioriGO/internal/models"
 )
 
+// MiningStats records per-level work done by Apriori. Index k-1 holds level k.
+type MiningStats struct {
+	LevelDurations       []time.Duration
+	LevelCandidateCounts []int
+	LevelFrequentCounts  []int
+
+	// Algorithm is the strategy that ran, as chosen for AlgorithmAuto
+	Algorithm Algorithm
+
+	// EstimatedAprioriMB is the Apriori memory estimate AlgorithmAuto compared with
+	// MemoryBudgetMB, or 0 when there was no budget to compare with
+	EstimatedAprioriMB float64
+}
+
+// record appends the figures for the next level
+func (s *MiningStats) record(duration time.Duration, candidates, frequent int) {
+	if s == nil {
+		return
+	}
+	s.LevelDurations = append(s.LevelDurations, duration)
+	s.LevelCandidateCounts = append(s.LevelCandidateCounts, candidates)
+	s.LevelFrequentCounts = append(s.LevelFrequentCounts, frequent)
+}
+
 // FindFrequentItemsets finds frequent itemsets using the Apriori algorithm
 func FindFrequentItemsets(dataset *models.Dataset, minSupport float64, maxLen int) []models.FrequentItemset {
+	return findFrequentItemsets(context.Background(), dataset, maxLen, constantThresholds(minSupport, maxLen), nil)
+}
+
+// FindFrequentItemsetsWithStats runs Apriori like FindFrequentItemsets and also
+// reports how long each level took and how many candidates it counted
+func FindFrequentItemsetsWithStats(dataset *models.Dataset, minSupport float64, maxLen int) ([]models.FrequentItemset, MiningStats) {
+	var stats MiningStats
+	itemsets := findFrequentItemsets(context.Background(), dataset, maxLen, constantThresholds(minSupport, maxLen), &stats)
+	return itemsets, stats
+}
+
+// findFrequentItemsets runs Apriori with per-length support thresholds, filling stats when it is not nil
+func findFrequentItemsets(ctx context.Context, dataset *models.Dataset, maxLen int, thresholds levelThresholds, stats *MiningStats) []models.FrequentItemset {
 	transactionCount := float64(len(dataset.Transactions))
 	result := make([]models.FrequentItemset, 0)
 
 	// Find frequent 1-itemsets
+	levelStart := time.Now()
+	counts := itemCounts(dataset)
 	L1 := make([]models.FrequentItemset, 0)
 	for _, item := range dataset.UniqueItems {
-		count := 0
-		for _, transaction := range dataset.Transactions {
-			if containsItem(transaction, item) {
-				count++
-			}
-		}
-
-		support := float64(count) / transactionCount
-		if support >= minSupport {
+		support := float64(counts[item]) / transactionCount
+		if thresholds.survives(1, support) {
 			L1 = append(L1, models.FrequentItemset{
 				Items:   []string{item},
 				Support: support,
 				Length:  1,
+				Count:   counts[item],
 			})
 		}
 	}
 
-	result = append(result, L1...)
+	result = appendReported(result, L1, thresholds)
+	stats.record(time.Since(levelStart), len(dataset.UniqueItems), len(L1))
+
+	// Infrequent items can never be part of a frequent itemset, so drop them
+	// from the transactions before counting longer candidates
+	transactions := pruneTransactions(dataset.Transactions, frequentItemSet(L1))
 
 	Lk_1 := L1
 	for k := 2; k <= maxLen; k++ {
+		levelStart = time.Now()
 		Ck := generateCandidates(Lk_1, k)
 
 		Lk := make([]models.FrequentItemset, 0)
 		for _, candidate := range Ck {
+			if ctx.Err() != nil {
+				return result
+			}
+
 			count := 0
-			for _, transaction := range dataset.Transactions {
+			for _, transaction := range transactions {
 				if isSubset(candidate.Items, transaction) {
 					count++
 				}
 			}
 
 			support := float64(count) / transactionCount
-			if support >= minSupport {
+			if thresholds.survives(k, support) {
 				Lk = append(Lk, models.FrequentItemset{
 					Items:   candidate.Items,
 					Support: support,
 					Length:  k,
+					Count:   count,
 				})
 			}
 		}
 
+		stats.record(time.Since(levelStart), len(Ck), len(Lk))
+
 		if len(Lk) == 0 {
 			break
 		}
 
-		result = append(result, Lk...)
+		result = appendReported(result, Lk, thresholds)
 		Lk_1 = Lk
 	}
 
 	return result
 }
 
+// appendReported appends the itemsets of a level that meet its reporting threshold
+func appendReported(result, level []models.FrequentItemset, thresholds levelThresholds) []models.FrequentItemset {
+	for _, itemset := range level {
+		if thresholds.reports(itemset.Length, itemset.Support) {
+			result = append(result, itemset)
+		}
+	}
+	return result
+}
+
 // generateCandidates generates candidate itemsets of size k from frequent itemsets of size k-1
 func generateCandidates(itemsets []models.FrequentItemset, k int) []models.FrequentItemset {
 	candidates := make([]models.FrequentItemset, 0)
 
+	// Every (k-1)-subset of a candidate must be frequent, looked up by key
+	frequent := make(map[string]bool, len(itemsets))
+	if k > 2 {
+		for _, itemset := range itemsets {
+			frequent[models.ItemsetKey(itemset.Items)] = true
+		}
+	}
+
 	for i := 0; i < len(itemsets); i++ {
 		for j := i + 1; j < len(itemsets); j++ {
 			if k > 2 {
@@ -104,15 +168,7 @@ func generateCandidates(itemsets []models.FrequentItemset, k int) []models.Frequ
 						copy(subset[l:], candidate[l+1:])
 					}
 
-					isFrequent := false
-					for _, itemset := range itemsets {
-						if slicesEqual(itemset.Items, subset) {
-							isFrequent = true
-							break
-						}
-					}
-
-					if !isFrequent {
+					if !frequent[models.ItemsetKey(subset)] {
 						isValid = false
 						break
 					}
@@ -135,72 +191,100 @@ func generateCandidates(itemsets []models.FrequentItemset, k int) []models.Frequ
 
 // GenerateAssociationRules generates association rules from frequent itemsets
 func GenerateAssociationRules(itemsets []models.FrequentItemset, minConfidence float64) []models.AssociationRule {
+	return GenerateRules(itemsets, RuleOptions{MinConfidence: minConfidence})
+}
+
+// GenerateRules generates association rules from frequent itemsets using the given options
+func GenerateRules(itemsets []models.FrequentItemset, opts RuleOptions) []models.AssociationRule {
 	rules := make([]models.AssociationRule, 0)
-	itemsetMap := make(map[string]float64)
+	StreamRules(itemsets, opts, func(rule models.AssociationRule) bool {
+		rules = append(rules, rule)
+		return true
+	})
+	return rules
+}
+
+// StreamRules generates association rules one at a time, passing each to emit as soon
+// as it is produced instead of collecting them. Generation stops when emit returns false.
+func StreamRules(itemsets []models.FrequentItemset, opts RuleOptions, emit func(models.AssociationRule) bool) {
+	defer models.StartPhase(opts.Observer, models.PhaseRules)()
 
-	// Create a map for quick lookup of itemset support
-	for _, itemset := range itemsets {
-		itemsetMap[strings.Join(itemset.Items, ",")] = itemset.Support
+	// Itemsets from outside the miner may list their items in any order
+	itemsets = models.CanonicalItemsets(itemsets)
+
+	// Look up subset supports among the itemsets, falling back to the dataset if given
+	resolver := NewSupportResolver(itemsets, opts.Dataset)
+	transactionCount := resolver.TransactionCount()
+	var segment *segmentCounter
+	if opts.Segment != nil {
+		segment = newSegmentCounter(opts.Dataset, opts.Segment)
+	}
+
+	sources := itemsets
+	if opts.MaximalOnly {
+		sources = FilterMaximal(itemsets)
 	}
 
 	// Generate rules for each itemset with length > 1
-	for _, itemset := range itemsets {
-		if itemset.Length <= 1 {
+	for _, itemset := range sources {
+		// An itemset with a repeated item, such as one rolled up to categories without
+		// deduplication, only yields rules with an item on both sides
+		if itemset.Length <= 1 || hasRepeatedItem(itemset.Items) {
+			continue
+		}
+		if opts.AllowedConsequents != nil && !anyWanted(itemset.Items, opts.AllowedConsequents) {
 			continue
 		}
 
-		// Generate all possible non-empty subsets as antecedents
-		antecedents := generateAllSubsets(itemset.Items)
+		// Find the antecedents that reach the confidence threshold, up to the length cap
+		maxAntecedent := len(itemset.Items) - 1
+		if opts.MaxAntecedentLength > 0 {
+			maxAntecedent = min(maxAntecedent, opts.MaxAntecedentLength)
+		}
+		antecedents := confidentAntecedents(itemset, resolver, opts.MinConfidence, maxAntecedent)
 
 		for _, antecedent := range antecedents {
-			// Skip if antecedent is empty or the same as the itemset
-			if len(antecedent) == 0 || len(antecedent) == len(itemset.Items) {
+			// Generate consequent. The itemset has no repeated item, so the two sides
+			// of the rule never share one.
+			consequent := difference(itemset.Items, antecedent)
+			if opts.AllowedConsequents != nil && !allWanted(consequent, opts.AllowedConsequents) {
 				continue
 			}
 
-			// Generate consequent
-			consequent := difference(itemset.Items, antecedent)
+			antecedentSupport, _ := resolver.Support(antecedent)
+			antecedentCount, _ := resolver.Count(antecedent)
 
-			// Get antecedent support
-			antecedentKey := strings.Join(antecedent, ",")
-			antecedentSupport, exists := itemsetMap[antecedentKey]
+			// Calculate additional metrics
+			consequentSupport, exists := resolver.Support(consequent)
 			if !exists {
 				continue // Should not happen with proper subsets
 			}
+			if consequentSupport == 0 {
+				continue // Lift is undefined, as the itemset never occurs either
+			}
 
-			// Calculate confidence
-			confidence := itemset.Support / antecedentSupport
-
-			if confidence >= minConfidence {
-				// Calculate additional metrics
-				consequentKey := strings.Join(consequent, ",")
-				consequentSupport, exists := itemsetMap[consequentKey]
-				if !exists {
-					continue // Should not happen with proper subsets
-				}
+			var revenue float64
+			if opts.Prices != nil {
+				confidence := itemset.Support / antecedentSupport
+				revenue = (confidence - consequentSupport) * basketValue(consequent, opts.Prices)
+			}
 
-				lift := confidence / consequentSupport
-				leverage := itemset.Support - (antecedentSupport * consequentSupport)
+			rule := newRule(antecedent, consequent, itemset.Support, antecedentSupport, consequentSupport)
+			rule.AntecedentCount = antecedentCount
+			rule.ItemsetCount = itemset.Count
+			rule.TransactionCount = transactionCount
+			rule.RevenueScore = revenue
 
-				var conviction float64
-				if consequentSupport == 1.0 || confidence == 1.0 {
-					conviction = math.Inf(1)
-				} else {
-					conviction = (1.0 - consequentSupport) / (1.0 - confidence)
-				}
+			if rule.Confidence >= 1 && antecedentCount < opts.PerfectRuleMinCount {
+				continue
+			}
+			if segment != nil {
+				segment.apply(&rule, itemset.Items)
+			}
 
-				rules = append(rules, models.AssociationRule{
-					Antecedent:       antecedent,
-					Consequent:       consequent,
-					Support:          itemset.Support,
-					Confidence:       confidence,
-					Lift:             lift,
-					LeverageMetric:   leverage,
-					ConvictionMetric: conviction,
-				})
+			if !emit(rule) {
+				return
 			}
 		}
 	}
-
-	return rules
 }
@@ -0,0 +1,169 @@
+package algorithm
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// findRule returns the rule with the given sides, if any
+func findRule(rules []models.AssociationRule, antecedent, consequent []string) (models.AssociationRule, bool) {
+	for _, rule := range rules {
+		if models.ItemsetKey(rule.Antecedent) == models.ItemsetKey(antecedent) &&
+			models.ItemsetKey(rule.Consequent) == models.ItemsetKey(consequent) {
+			return rule, true
+		}
+	}
+	return models.AssociationRule{}, false
+}
+
+// assertFiniteRules checks that no rule has an undefined metric or a side that never occurs
+func assertFiniteRules(t *testing.T, rules []models.AssociationRule) {
+	t.Helper()
+	for _, rule := range rules {
+		if math.IsNaN(rule.Confidence) || math.IsInf(rule.Confidence, 0) || math.IsNaN(rule.Lift) || math.IsInf(rule.Lift, 0) {
+			t.Errorf("rule %v => %v has confidence %v and lift %v", rule.Antecedent, rule.Consequent, rule.Confidence, rule.Lift)
+		}
+		if rule.AntecedentSupport == 0 || rule.ConsequentSupport == 0 {
+			t.Errorf("rule %v => %v has a side that never occurs", rule.Antecedent, rule.Consequent)
+		}
+	}
+}
+
+func TestRulesZeroSupportThreshold(t *testing.T) {
+	dataset := models.NewDataset([]models.Transaction{{"a", "b"}, {"a"}, {"c"}})
+
+	for _, algorithm := range []Algorithm{AlgorithmApriori, AlgorithmEclat} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			itemsets, err := MineItemsets(dataset, MineOptions{MinSupport: 0, MaxLength: 3, Algorithm: algorithm})
+			if err != nil {
+				t.Fatalf("MineItemsets: %v", err)
+			}
+			rules := GenerateRules(itemsets, RuleOptions{MinConfidence: 0})
+			assertFiniteRules(t, rules)
+
+			// {b,c} never occurs, so {b,c} => {a} is skipped for its antecedent and
+			// {a} => {b,c} for its consequent
+			if _, ok := findRule(rules, []string{"b", "c"}, []string{"a"}); ok {
+				t.Error("got a rule with the zero-support antecedent {b,c}")
+			}
+			if _, ok := findRule(rules, []string{"a"}, []string{"b", "c"}); ok {
+				t.Error("got a rule with the zero-support consequent {b,c}")
+			}
+
+			// A zero-support antecedent must not rule out its subsets
+			rule, ok := findRule(rules, []string{"c"}, []string{"a", "b"})
+			if !ok {
+				t.Fatal("missing {c} => {a,b}, a subset of the zero-support antecedent {b,c}")
+			}
+			if rule.Confidence != 0 || rule.Lift != 0 {
+				t.Errorf("{c} => {a,b} has confidence %v and lift %v, want 0 and 0", rule.Confidence, rule.Lift)
+			}
+			if _, ok := findRule(rules, []string{"a"}, []string{"b"}); !ok {
+				t.Error("missing {a} => {b}")
+			}
+		})
+	}
+}
+
+func TestRulesZeroSupportLoadedItemsets(t *testing.T) {
+	// Itemsets from a file can be inconsistent, with a side rarer than the whole itemset
+	itemsets := []models.FrequentItemset{
+		{Items: []string{"a"}, Support: 0, Length: 1},
+		{Items: []string{"b"}, Support: 0.5, Length: 1},
+		{Items: []string{"a", "b"}, Support: 0.5, Length: 2},
+	}
+	rules := GenerateAssociationRules(itemsets, 0)
+	assertFiniteRules(t, rules)
+	if len(rules) != 0 {
+		t.Errorf("got %d rules, want none as {a} never occurs", len(rules))
+	}
+}
+
+func TestGenerateRulesUnsortedItemsets(t *testing.T) {
+	single := func(item string, count int) models.FrequentItemset {
+		return models.FrequentItemset{Items: []string{item}, Support: float64(count) / 4, Length: 1, Count: count}
+	}
+	pair := func(items ...string) models.FrequentItemset {
+		return models.FrequentItemset{Items: items, Support: 0.5, Length: 2, Count: 2}
+	}
+
+	sorted := GenerateAssociationRules([]models.FrequentItemset{single("a", 3), single("b", 2), pair("a", "b")}, 0.5)
+	unsorted := GenerateAssociationRules([]models.FrequentItemset{single("a", 3), single("b", 2), pair("b", "a"), pair("a", "b")}, 0.5)
+
+	if len(sorted) != 2 {
+		t.Fatalf("got %d rules from sorted itemsets, want 2", len(sorted))
+	}
+	if !reflect.DeepEqual(unsorted, sorted) {
+		t.Errorf("rules from {b,a} and {a,b} differ from those of {a,b} alone:\ngot  %+v\nwant %+v", unsorted, sorted)
+	}
+}
+
+func TestRulesRolledUpToCategories(t *testing.T) {
+	// Rolling {whole milk, skim milk, bread} up to categories without deduplication
+	// repeats dairy, while an item next to its own category is a valid itemset
+	itemsets := []models.FrequentItemset{
+		{Items: []string{"bakery"}, Support: 0.5, Length: 1, Count: 2},
+		{Items: []string{"dairy"}, Support: 0.75, Length: 1, Count: 3},
+		{Items: []string{"milk"}, Support: 0.5, Length: 1, Count: 2},
+		{Items: []string{"dairy", "dairy"}, Support: 0.5, Length: 2, Count: 2},
+		{Items: []string{"bakery", "dairy"}, Support: 0.5, Length: 2, Count: 2},
+		{Items: []string{"dairy", "milk"}, Support: 0.5, Length: 2, Count: 2},
+		{Items: []string{"bakery", "dairy", "dairy"}, Support: 0.5, Length: 3, Count: 2},
+	}
+
+	for name, rules := range map[string][]models.AssociationRule{
+		"GenerateAssociationRules": GenerateAssociationRules(itemsets, 0),
+		"GeneratePairRules":        GeneratePairRules(itemsets, 0),
+	} {
+		for _, rule := range rules {
+			for _, item := range rule.Antecedent {
+				if containsItem(rule.Consequent, item) {
+					t.Errorf("%s: rule %v => %v has %q on both sides", name, rule.Antecedent, rule.Consequent, item)
+				}
+			}
+		}
+		for _, sides := range [][2][]string{{{"bakery"}, {"dairy"}}, {{"milk"}, {"dairy"}}, {{"dairy"}, {"milk"}}} {
+			if _, ok := findRule(rules, sides[0], sides[1]); !ok {
+				t.Errorf("%s: missing %v => %v", name, sides[0], sides[1])
+			}
+		}
+		if len(rules) != 4 {
+			t.Errorf("%s: got %d rules, want the 4 between distinct items", name, len(rules))
+		}
+	}
+}
+
+func TestRulesMaxAntecedentLength(t *testing.T) {
+	itemsets, err := MineItemsets(models.NewDataset([]models.Transaction{
+		{"a", "b", "c", "d"}, {"a", "b", "c", "d"}, {"a", "b", "c"}, {"a", "b", "d"},
+		{"a", "c", "d"}, {"b", "c", "d"}, {"a", "b"}, {"c", "d"},
+	}), MineOptions{MinSupport: 0.2, MaxLength: 4})
+	if err != nil {
+		t.Fatalf("MineItemsets: %v", err)
+	}
+	uncapped := GenerateRules(itemsets, RuleOptions{MinConfidence: 0.3})
+
+	for maxAntecedent := 1; maxAntecedent <= 3; maxAntecedent++ {
+		want := make([]models.AssociationRule, 0)
+		for _, rule := range uncapped {
+			if len(rule.Antecedent) <= maxAntecedent {
+				want = append(want, rule)
+			}
+		}
+		got := GenerateRules(itemsets, RuleOptions{MinConfidence: 0.3, MaxAntecedentLength: maxAntecedent})
+		for _, rule := range got {
+			if len(rule.Antecedent) > maxAntecedent {
+				t.Errorf("max antecedent %d: rule %v => %v has a longer antecedent", maxAntecedent, rule.Antecedent, rule.Consequent)
+			}
+		}
+		if len(want) == 0 {
+			t.Fatalf("max antecedent %d: no rules to compare", maxAntecedent)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("max antecedent %d: got %d rules, want the %d uncapped rules with short enough antecedents", maxAntecedent, len(got), len(want))
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// RuleBlocklist suppresses known-trivial rules. A rule is blocked when its antecedent
+// and consequent are exactly those of a blocked rule, whatever the item order; rules
+// that merely contain a blocked rule's items are kept.
+type RuleBlocklist struct {
+	keys map[string]bool
+}
+
+// NewRuleBlocklist creates a blocklist of the antecedent and consequent of each rule,
+// e.g. as loaded by loader.LoadRuleBlocklist
+func NewRuleBlocklist(rules []models.AssociationRule) *RuleBlocklist {
+	keys := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		keys[ruleKey(rule)] = true
+	}
+	return &RuleBlocklist{keys: keys}
+}
+
+// Blocks reports whether the rule is on the blocklist
+func (b *RuleBlocklist) Blocks(rule models.AssociationRule) bool {
+	return b.keys[ruleKey(rule)]
+}
+
+// Filter returns the rules that are not on the blocklist, in their original order
+func (b *RuleBlocklist) Filter(rules []models.AssociationRule) []models.AssociationRule {
+	kept := make([]models.AssociationRule, 0, len(rules))
+	for _, rule := range rules {
+		if !b.Blocks(rule) {
+			kept = append(kept, rule)
+		}
+	}
+	return kept
+}
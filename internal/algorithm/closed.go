@@ -0,0 +1,144 @@
+package algorithm
+
+import (
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// FilterClosed returns the closed itemsets: those with no frequent proper superset of
+// the same support. Unlike the maximal itemsets they keep enough information to recover
+// the support of every frequent itemset. The input order is preserved.
+func FilterClosed(itemsets []models.FrequentItemset) []models.FrequentItemset {
+	reported := make(map[string]bool, len(itemsets))
+	for _, itemset := range itemsets {
+		reported[models.ItemsetKey(itemset.Items)] = true
+	}
+
+	// Visit longer itemsets first so every superset has passed its support down to
+	// its subsets before the subset itself is checked
+	order := make([]int, len(itemsets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(itemsets[order[a]].Items) > len(itemsets[order[b]].Items)
+	})
+
+	superSupport := make(map[string]float64)
+	closed := make([]bool, len(itemsets))
+	for _, i := range order {
+		itemset := itemsets[i]
+		best, ok := superSupport[models.ItemsetKey(itemset.Items)]
+		closed[i] = !ok || best < itemset.Support
+		markSuperSupport(itemset.Items, itemset.Support, superSupport, reported)
+	}
+
+	result := make([]models.FrequentItemset, 0)
+	for i, itemset := range itemsets {
+		if closed[i] {
+			result = append(result, itemset)
+		}
+	}
+	return result
+}
+
+// markSuperSupport records support as a superset support of the immediate subsets of
+// items, keeping the highest seen per subset. Subsets that were not reported themselves
+// are descended into, like in markCovered.
+func markSuperSupport(items []string, support float64, superSupport map[string]float64, reported map[string]bool) {
+	if len(items) <= 1 {
+		return
+	}
+
+	for skip := range items {
+		subset := make([]string, 0, len(items)-1)
+		subset = append(subset, items[:skip]...)
+		subset = append(subset, items[skip+1:]...)
+
+		key := models.ItemsetKey(subset)
+		if best, ok := superSupport[key]; ok && best >= support {
+			continue
+		}
+		superSupport[key] = support
+		if !reported[key] {
+			markSuperSupport(subset, support, superSupport, reported)
+		}
+	}
+}
+
+// GeneratorGroup is a closed itemset with the minimal generators that share its support
+type GeneratorGroup struct {
+	Closure    models.FrequentItemset
+	Generators []models.FrequentItemset
+}
+
+// MinimalGenerators groups the itemsets by closure, the closed itemset containing them
+// with the same support, and returns the minimal generators of each closed itemset: the
+// itemsets of that closure with no proper subset of the same support. Together with the
+// closed itemsets they give a non-redundant basis for rules. Groups follow the order of
+// the closed itemsets in the input and generators their own input order. Subsets missing
+// from the input are assumed to have a higher support, and a closed itemset whose only
+// generator is the empty set (items present in every transaction) gets no group.
+func MinimalGenerators(itemsets []models.FrequentItemset) []GeneratorGroup {
+	supports := make(map[string]float64, len(itemsets))
+	for _, itemset := range itemsets {
+		supports[models.ItemsetKey(itemset.Items)] = itemset.Support
+	}
+
+	closed := FilterClosed(itemsets)
+	bySupport := make(map[float64][]int)
+	for i, itemset := range closed {
+		bySupport[itemset.Support] = append(bySupport[itemset.Support], i)
+	}
+
+	generators := make([][]models.FrequentItemset, len(closed))
+	for _, itemset := range itemsets {
+		if !isMinimalGenerator(itemset, supports) {
+			continue
+		}
+		if i, ok := closureOf(itemset, closed, bySupport[itemset.Support]); ok {
+			generators[i] = append(generators[i], itemset)
+		}
+	}
+
+	groups := make([]GeneratorGroup, 0)
+	for i, closure := range closed {
+		if len(generators[i]) > 0 {
+			groups = append(groups, GeneratorGroup{Closure: closure, Generators: generators[i]})
+		}
+	}
+	return groups
+}
+
+// isMinimalGenerator reports whether every immediate subset of an itemset has a
+// different support. A proper subset with the same support always implies an
+// immediate one, so these are the only subsets that need checking.
+func isMinimalGenerator(itemset models.FrequentItemset, supports map[string]float64) bool {
+	items := itemset.Items
+	if len(items) == 1 {
+		return itemset.Support < 1
+	}
+
+	for skip := range items {
+		subset := make([]string, 0, len(items)-1)
+		subset = append(subset, items[:skip]...)
+		subset = append(subset, items[skip+1:]...)
+		if support, ok := supports[models.ItemsetKey(subset)]; ok && support == itemset.Support {
+			return false
+		}
+	}
+	return true
+}
+
+// closureOf finds the index of the longest closed itemset among candidates that
+// contains the given itemset
+func closureOf(itemset models.FrequentItemset, closed []models.FrequentItemset, candidates []int) (int, bool) {
+	best := -1
+	for _, i := range candidates {
+		if (best < 0 || len(closed[i].Items) > len(closed[best].Items)) && isSubset(itemset.Items, closed[i].Items) {
+			best = i
+		}
+	}
+	return best, best >= 0
+}
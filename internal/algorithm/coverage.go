@@ -0,0 +1,34 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// RuleSetCoverage returns the fraction of transactions that contain the full
+// antecedent of at least one rule
+func RuleSetCoverage(rules []models.AssociationRule, dataset *models.Dataset) float64 {
+	if len(dataset.Transactions) == 0 {
+		return 0
+	}
+
+	covered := 0
+	for _, transaction := range dataset.Transactions {
+		for _, rule := range rules {
+			if isSubset(rule.Antecedent, transaction) {
+				covered++
+				break
+			}
+		}
+	}
+
+	return float64(covered) / float64(len(dataset.Transactions))
+}
+
+// RuleCoverage returns, for each rule, the fraction of transactions containing its antecedent
+func RuleCoverage(rules []models.AssociationRule, dataset *models.Dataset) []float64 {
+	coverage := make([]float64, len(rules))
+	for i, rule := range rules {
+		coverage[i] = Support(dataset, rule.Antecedent)
+	}
+	return coverage
+}
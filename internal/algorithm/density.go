@@ -0,0 +1,78 @@
+package algorithm
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Thresholds of the density advice. Dense data makes Apriori generate and rescan many
+// candidates per level, which FP-Growth's shared prefix tree avoids; long baskets make
+// every Apriori level an expensive scan, which Eclat's tidset intersections avoid.
+const (
+	denseThreshold      = 0.1
+	longBasketThreshold = 15.0
+)
+
+// DensityReport describes the shape of a dataset and the algorithm that suits it
+type DensityReport struct {
+	AverageBasketSize float64
+	ItemCount         int
+
+	// Density is the average basket size divided by the number of distinct items,
+	// the fraction of all items an average basket holds
+	Density float64
+
+	// Skew is the Gini coefficient of the item frequencies: 0 when every item is
+	// equally common, approaching 1 when a few items make up almost every occurrence
+	Skew float64
+
+	Recommended    Algorithm
+	Recommendation string // Advice for users, e.g. "dense dataset; consider -algorithm fpgrowth"
+}
+
+// AnalyzeDensity measures a dataset's density and item frequency skew in one pass and
+// recommends an algorithm. The advice is a heuristic; every algorithm finds the same
+// itemsets.
+func AnalyzeDensity(dataset *models.Dataset) DensityReport {
+	report := DensityReport{ItemCount: len(dataset.UniqueItems), Recommended: AlgorithmApriori}
+	if len(dataset.Transactions) == 0 || report.ItemCount == 0 {
+		report.Recommendation = "empty dataset"
+		return report
+	}
+
+	counts := itemCounts(dataset)
+	occurrences := 0
+	frequencies := make([]int, 0, len(counts))
+	for _, count := range counts {
+		occurrences += count
+		frequencies = append(frequencies, count)
+	}
+	report.AverageBasketSize = float64(occurrences) / float64(len(dataset.Transactions))
+	report.Density = report.AverageBasketSize / float64(report.ItemCount)
+	report.Skew = gini(frequencies, occurrences)
+
+	switch {
+	case report.Density >= denseThreshold:
+		report.Recommended = AlgorithmFPGrowth
+		report.Recommendation = fmt.Sprintf("dense dataset; consider -algorithm %s", AlgorithmFPGrowth)
+	case report.AverageBasketSize >= longBasketThreshold:
+		report.Recommended = AlgorithmEclat
+		report.Recommendation = fmt.Sprintf("sparse dataset with long baskets; consider -algorithm %s", AlgorithmEclat)
+	default:
+		report.Recommendation = fmt.Sprintf("sparse dataset; %s is a good fit", AlgorithmApriori)
+	}
+	return report
+}
+
+// gini computes the Gini coefficient of counts summing to total
+func gini(counts []int, total int) float64 {
+	sort.Ints(counts)
+	n := float64(len(counts))
+	weighted := 0.0
+	for i, count := range counts {
+		weighted += float64(i+1) * float64(count)
+	}
+	return 2*weighted/(n*float64(total)) - (n+1)/n
+}
@@ -0,0 +1,75 @@
+package algorithm
+
+import (
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// metricTolerance is the smallest metric difference reported as a change
+const metricTolerance = 1e-9
+
+// RuleChange pairs the old and new versions of a rule whose metrics changed
+type RuleChange struct {
+	Old models.AssociationRule
+	New models.AssociationRule
+}
+
+// RuleDiff holds the result of comparing two mined rule sets
+type RuleDiff struct {
+	Added   []models.AssociationRule
+	Removed []models.AssociationRule
+	Changed []RuleChange
+}
+
+// DiffRules compares two rule sets, matching rules by antecedent and consequent
+func DiffRules(oldRules, newRules []models.AssociationRule) RuleDiff {
+	diff := RuleDiff{
+		Added:   make([]models.AssociationRule, 0),
+		Removed: make([]models.AssociationRule, 0),
+		Changed: make([]RuleChange, 0),
+	}
+
+	oldMap := make(map[string]models.AssociationRule, len(oldRules))
+	for _, rule := range oldRules {
+		oldMap[ruleKey(rule)] = rule
+	}
+
+	newKeys := make(map[string]bool, len(newRules))
+	for _, rule := range newRules {
+		key := ruleKey(rule)
+		newKeys[key] = true
+
+		previous, exists := oldMap[key]
+		if !exists {
+			diff.Added = append(diff.Added, rule)
+			continue
+		}
+
+		if metricChanged(previous.Confidence, rule.Confidence) || metricChanged(previous.Lift, rule.Lift) {
+			diff.Changed = append(diff.Changed, RuleChange{Old: previous, New: rule})
+		}
+	}
+
+	// Keep removed rules in the order they appeared in the old set
+	for _, rule := range oldRules {
+		if !newKeys[ruleKey(rule)] {
+			diff.Removed = append(diff.Removed, rule)
+		}
+	}
+
+	return diff
+}
+
+// ruleKey builds the identity of a rule from its antecedent and consequent
+func ruleKey(rule models.AssociationRule) string {
+	return models.ItemsetKey(rule.Antecedent) + ruleKeySeparator + models.ItemsetKey(rule.Consequent)
+}
+
+// metricChanged reports whether two metric values differ beyond the tolerance
+func metricChanged(a, b float64) bool {
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a != b
+	}
+	return math.Abs(a-b) > metricTolerance
+}
@@ -0,0 +1,102 @@
+package algorithm
+
+import (
+	"context"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// tidsetNode is an itemset together with the IDs of the transactions containing it
+type tidsetNode struct {
+	items []string
+	tids  []int
+}
+
+// FindFrequentItemsetsEclat finds frequent itemsets with the Eclat algorithm, which
+// intersects per-item transaction ID lists instead of rescanning transactions
+func FindFrequentItemsetsEclat(dataset *models.Dataset, minSupport float64, maxLen int) []models.FrequentItemset {
+	return findFrequentItemsetsEclat(context.Background(), dataset, maxLen, constantThresholds(minSupport, maxLen))
+}
+
+// findFrequentItemsetsEclat runs Eclat with per-length support thresholds
+func findFrequentItemsetsEclat(ctx context.Context, dataset *models.Dataset, maxLen int, thresholds levelThresholds) []models.FrequentItemset {
+	transactionCount := float64(len(dataset.Transactions))
+	return eclat(ctx, dataset, maxLen, thresholds, func(tids []int) float64 {
+		return float64(len(tids)) / transactionCount
+	})
+}
+
+// findWeightedItemsetsEclat runs Eclat with support measured as the share of the total
+// transaction weight held by the transactions containing an itemset
+func findWeightedItemsetsEclat(ctx context.Context, dataset *models.Dataset, weights []float64, maxLen int, thresholds levelThresholds) []models.FrequentItemset {
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	return eclat(ctx, dataset, maxLen, thresholds, func(tids []int) float64 {
+		return weightedSupport(tids, weights, totalWeight)
+	})
+}
+
+// eclat mines depth-first over tidsets, measuring each itemset with support
+func eclat(ctx context.Context, dataset *models.Dataset, maxLen int, thresholds levelThresholds, support func(tids []int) float64) []models.FrequentItemset {
+	result := make([]models.FrequentItemset, 0)
+
+	// The vertical layout: item -> sorted transaction IDs
+	tidsets := dataset.InvertedIndex()
+
+	// Find frequent 1-itemsets in sorted item order
+	roots := make([]tidsetNode, 0)
+	for _, item := range dataset.UniqueItems {
+		tids := tidsets[item]
+		if thresholds.survives(1, support(tids)) {
+			roots = append(roots, tidsetNode{items: []string{item}, tids: tids})
+		}
+	}
+
+	var extend func(class []tidsetNode)
+	extend = func(class []tidsetNode) {
+		for i, node := range class {
+			if ctx.Err() != nil {
+				return
+			}
+
+			nodeSupport := support(node.tids)
+			if thresholds.reports(len(node.items), nodeSupport) {
+				result = append(result, models.FrequentItemset{
+					Items:   node.items,
+					Support: nodeSupport,
+					Length:  len(node.items),
+					Count:   len(node.tids),
+				})
+			}
+
+			if len(node.items) >= maxLen {
+				continue
+			}
+
+			// Combine with every later sibling sharing the same prefix
+			next := make([]tidsetNode, 0)
+			for _, sibling := range class[i+1:] {
+				tids := intersectSorted(node.tids, sibling.tids)
+				if !thresholds.survives(len(node.items)+1, support(tids)) {
+					continue
+				}
+
+				items := make([]string, len(node.items)+1)
+				copy(items, node.items)
+				items[len(node.items)] = sibling.items[len(sibling.items)-1]
+				next = append(next, tidsetNode{items: items, tids: tids})
+			}
+
+			if len(next) > 0 {
+				extend(next)
+			}
+		}
+	}
+
+	extend(roots)
+	sortItemsetsCanonical(result)
+
+	return result
+}
@@ -0,0 +1,26 @@
+package algorithm
+
+import (
+	"errors"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Sentinel errors for errors.Is. Returned errors wrap these with details.
+var (
+	// ErrEmptyDataset is the same value as models.ErrEmptyDataset
+	ErrEmptyDataset = models.ErrEmptyDataset
+
+	// ErrNoFrequentItemsets is returned with an empty result when no itemset meets the
+	// support threshold; callers may treat it as a normal outcome
+	ErrNoFrequentItemsets = errors.New("no frequent itemsets found")
+
+	// ErrInvalidSupport is returned for a support threshold outside [0, 1]
+	ErrInvalidSupport = errors.New("invalid support threshold")
+
+	// ErrInvalidOption is returned for any other option or name that is out of range or unknown
+	ErrInvalidOption = errors.New("invalid option")
+
+	// ErrInvalidRule is returned for a rule whose metrics cannot be computed
+	ErrInvalidRule = errors.New("invalid rule")
+)
@@ -0,0 +1,79 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// LevelEstimate describes the expected size of one level of the Apriori search
+type LevelEstimate struct {
+	Level      int
+	Candidates int
+	Frequent   int
+	Counted    bool // Whether Frequent was counted; otherwise only candidates are known
+}
+
+// EstimateLevels cheaply sizes the first levels of a mining run without generating
+// rules: L1 and the frequent pairs are counted exactly, and the level-3 candidate
+// count is derived from the frequent pairs
+func EstimateLevels(dataset *models.Dataset, minSupport float64) []LevelEstimate {
+	transactionCount := float64(len(dataset.Transactions))
+	estimates := make([]LevelEstimate, 0, 3)
+
+	// Level 1: every unique item is a candidate
+	counts := itemCounts(dataset)
+	L1 := make([]models.FrequentItemset, 0)
+	for _, item := range dataset.UniqueItems {
+		if float64(counts[item])/transactionCount >= minSupport {
+			L1 = append(L1, models.FrequentItemset{Items: []string{item}, Length: 1})
+		}
+	}
+	estimates = append(estimates, LevelEstimate{
+		Level:      1,
+		Candidates: len(dataset.UniqueItems),
+		Frequent:   len(L1),
+		Counted:    true,
+	})
+
+	if len(L1) < 2 {
+		return estimates
+	}
+
+	// Level 2: count every pair of frequent items in one pass
+	transactions := pruneTransactions(dataset.Transactions, frequentItemSet(L1))
+	pairCounts := make(map[[2]string]int)
+	for _, transaction := range transactions {
+		items := sortedCopy(transaction)
+		for i := 0; i < len(items); i++ {
+			for j := i + 1; j < len(items); j++ {
+				pairCounts[[2]string{items[i], items[j]}]++
+			}
+		}
+	}
+
+	L2 := make([]models.FrequentItemset, 0)
+	for pair, count := range pairCounts {
+		if float64(count)/transactionCount >= minSupport {
+			L2 = append(L2, models.FrequentItemset{Items: []string{pair[0], pair[1]}, Length: 2})
+		}
+	}
+	sortItemsetsCanonical(L2)
+
+	estimates = append(estimates, LevelEstimate{
+		Level:      2,
+		Candidates: len(L1) * (len(L1) - 1) / 2,
+		Frequent:   len(L2),
+		Counted:    true,
+	})
+
+	if len(L2) < 2 {
+		return estimates
+	}
+
+	// Level 3: only the candidate count, which bounds the frequent triples
+	estimates = append(estimates, LevelEstimate{
+		Level:      3,
+		Candidates: len(generateCandidates(L2, 3)),
+	})
+
+	return estimates
+}
@@ -0,0 +1,103 @@
+package algorithm
+
+import (
+	"fmt"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Side selects which side of a rule an item filter looks at
+type Side int
+
+const (
+	SideAntecedent Side = iota
+	SideConsequent
+	SideEither
+)
+
+// ParseSide converts a side name (antecedent, consequent or either) to a Side
+func ParseSide(name string) (Side, error) {
+	switch name {
+	case "antecedent":
+		return SideAntecedent, nil
+	case "consequent":
+		return SideConsequent, nil
+	case "either":
+		return SideEither, nil
+	default:
+		return SideEither, fmt.Errorf("%w: unknown rule side %q, must be antecedent, consequent or either", ErrInvalidOption, name)
+	}
+}
+
+// FilterRulesByItems keeps the rules that mention at least one of the given items on
+// the selected side. A rule is kept once even if an item appears on both sides.
+func FilterRulesByItems(rules []models.AssociationRule, items []string, side Side) []models.AssociationRule {
+	wanted := make(map[string]bool, len(items))
+	for _, item := range items {
+		wanted[item] = true
+	}
+
+	filtered := make([]models.AssociationRule, 0)
+	for _, rule := range rules {
+		if MentionsItems(rule, wanted, side) {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
+
+// MentionsItems reports whether a rule has one of the wanted items on the selected side
+func MentionsItems(rule models.AssociationRule, wanted map[string]bool, side Side) bool {
+	if side != SideConsequent && anyWanted(rule.Antecedent, wanted) {
+		return true
+	}
+	if side != SideAntecedent && anyWanted(rule.Consequent, wanted) {
+		return true
+	}
+	return false
+}
+
+// anyWanted reports whether any of the items is in the wanted set
+func anyWanted(items []string, wanted map[string]bool) bool {
+	for _, item := range items {
+		if wanted[item] {
+			return true
+		}
+	}
+	return false
+}
+
+// allWanted reports whether every item is in the wanted set
+func allWanted(items []string, wanted map[string]bool) bool {
+	for _, item := range items {
+		if !wanted[item] {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterItemsetsByItems keeps the itemsets containing at least minMatches distinct
+// items of the interest list, in their original order, so one broad mining result can
+// be sliced by many focus lists without mining again. A minMatches of zero or less
+// keeps every itemset.
+func FilterItemsetsByItems(itemsets []models.FrequentItemset, interest []string, minMatches int) []models.FrequentItemset {
+	wanted := make(map[string]bool, len(interest))
+	for _, item := range interest {
+		wanted[item] = true
+	}
+
+	filtered := make([]models.FrequentItemset, 0)
+	for _, itemset := range itemsets {
+		matches := 0
+		for _, item := range itemset.Items {
+			if wanted[item] {
+				matches++
+			}
+		}
+		if matches >= minMatches {
+			filtered = append(filtered, itemset)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,44 @@
+package algorithm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+func TestFilterRulesByItems(t *testing.T) {
+	rule := func(antecedent, consequent string) models.AssociationRule {
+		return models.AssociationRule{Antecedent: []string{antecedent}, Consequent: []string{consequent}}
+	}
+	milkBread := rule("milk", "bread")
+	breadMilk := rule("bread", "milk")
+	eggsJam := rule("eggs", "jam")
+	// Hand-built or loaded rules can repeat an item across the sides
+	milkMilk := models.AssociationRule{Antecedent: []string{"milk"}, Consequent: []string{"milk", "jam"}}
+	rules := []models.AssociationRule{milkBread, breadMilk, eggsJam, milkMilk}
+
+	tests := []struct {
+		name  string
+		items []string
+		side  Side
+		want  []models.AssociationRule
+	}{
+		{"antecedent", []string{"milk"}, SideAntecedent, []models.AssociationRule{milkBread, milkMilk}},
+		{"consequent", []string{"milk"}, SideConsequent, []models.AssociationRule{breadMilk, milkMilk}},
+		{"either", []string{"milk"}, SideEither, []models.AssociationRule{milkBread, breadMilk, milkMilk}},
+		{"items on both sides", []string{"milk", "bread"}, SideEither, []models.AssociationRule{milkBread, breadMilk, milkMilk}},
+		{"items on both sides, antecedent", []string{"milk", "bread"}, SideAntecedent, []models.AssociationRule{milkBread, breadMilk, milkMilk}},
+		{"consequent only item", []string{"jam"}, SideAntecedent, []models.AssociationRule{}},
+		{"unknown item", []string{"tea"}, SideEither, []models.AssociationRule{}},
+		{"no items", nil, SideEither, []models.AssociationRule{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := FilterRulesByItems(rules, test.items, test.side)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("FilterRulesByItems(%q, %v) = %v, want %v", test.items, test.side, got, test.want)
+			}
+		})
+	}
+}
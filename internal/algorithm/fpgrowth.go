@@ -0,0 +1,178 @@
+package algorithm
+
+import (
+	"context"
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// fpNode is a node of an FP-tree
+type fpNode struct {
+	item     string
+	count    int
+	parent   *fpNode
+	children map[string]*fpNode
+}
+
+// fpTree is a prefix tree of transactions ordered by descending item frequency
+type fpTree struct {
+	root   *fpNode
+	header map[string][]*fpNode // Item -> every node holding that item
+	counts map[string]int       // Item -> total count in this tree
+	order  []string             // Frequent items, most frequent first
+}
+
+// FindFrequentItemsetsFPGrowth finds frequent itemsets with the FP-Growth algorithm,
+// which compresses transactions into a prefix tree and mines it without candidate generation
+func FindFrequentItemsetsFPGrowth(dataset *models.Dataset, minSupport float64, maxLen int) []models.FrequentItemset {
+	return findFrequentItemsetsFPGrowth(context.Background(), dataset, maxLen, constantThresholds(minSupport, maxLen))
+}
+
+// findFrequentItemsetsFPGrowth runs FP-Growth with per-length support thresholds
+func findFrequentItemsetsFPGrowth(ctx context.Context, dataset *models.Dataset, maxLen int, thresholds levelThresholds) []models.FrequentItemset {
+	transactionCount := float64(len(dataset.Transactions))
+	result := make([]models.FrequentItemset, 0)
+
+	// An item of a conditional tree extends the suffix to an itemset of length k
+	frequent := func(count, k int) bool {
+		return thresholds.survives(k, float64(count)/transactionCount)
+	}
+
+	paths := make([][]string, len(dataset.Transactions))
+	weights := make([]int, len(dataset.Transactions))
+	for i, transaction := range dataset.Transactions {
+		paths[i] = transaction
+		weights[i] = 1
+	}
+
+	tree := buildFPTree(paths, weights, 1, frequent)
+	tree.mine(ctx, nil, maxLen, frequent, func(items []string, count int) {
+		support := float64(count) / transactionCount
+		if !thresholds.reports(len(items), support) {
+			return
+		}
+
+		sort.Strings(items)
+		result = append(result, models.FrequentItemset{
+			Items:   items,
+			Support: support,
+			Length:  len(items),
+			Count:   count,
+		})
+	})
+
+	sortItemsetsCanonical(result)
+
+	return result
+}
+
+// buildFPTree builds an FP-tree from weighted item paths, keeping only items that are
+// frequent as the k-th item of an itemset
+func buildFPTree(paths [][]string, weights []int, k int, frequent func(count, k int) bool) *fpTree {
+	tree := &fpTree{
+		root:   &fpNode{children: make(map[string]*fpNode)},
+		header: make(map[string][]*fpNode),
+		counts: make(map[string]int),
+	}
+
+	counts := make(map[string]int)
+	for i, path := range paths {
+		for _, item := range path {
+			counts[item] += weights[i]
+		}
+	}
+
+	for item, count := range counts {
+		if frequent(count, k) {
+			tree.order = append(tree.order, item)
+			tree.counts[item] = count
+		}
+	}
+
+	// Most frequent first, ties broken by name so the tree shape is deterministic
+	sort.Slice(tree.order, func(i, j int) bool {
+		a, b := tree.order[i], tree.order[j]
+		if tree.counts[a] != tree.counts[b] {
+			return tree.counts[a] > tree.counts[b]
+		}
+		return a < b
+	})
+
+	rank := make(map[string]int, len(tree.order))
+	for i, item := range tree.order {
+		rank[item] = i
+	}
+
+	for i, path := range paths {
+		filtered := make([]string, 0, len(path))
+		for _, item := range path {
+			if _, ok := rank[item]; ok {
+				filtered = append(filtered, item)
+			}
+		}
+		sort.Slice(filtered, func(a, b int) bool { return rank[filtered[a]] < rank[filtered[b]] })
+
+		tree.insert(filtered, weights[i])
+	}
+
+	return tree
+}
+
+// insert adds an ordered path to the tree with the given weight
+func (t *fpTree) insert(path []string, weight int) {
+	node := t.root
+	for _, item := range path {
+		child, exists := node.children[item]
+		if !exists {
+			child = &fpNode{item: item, parent: node, children: make(map[string]*fpNode)}
+			node.children[item] = child
+			t.header[item] = append(t.header[item], child)
+		}
+		child.count += weight
+		node = child
+	}
+}
+
+// mine emits every frequent itemset of the tree extended with the suffix, stopping
+// early when ctx is cancelled
+func (t *fpTree) mine(ctx context.Context, suffix []string, maxLen int, frequent func(count, k int) bool, emit func(items []string, count int)) {
+	// Process items from least to most frequent
+	for i := len(t.order) - 1; i >= 0; i-- {
+		if ctx.Err() != nil {
+			return
+		}
+		item := t.order[i]
+
+		items := make([]string, 0, len(suffix)+1)
+		items = append(items, item)
+		items = append(items, suffix...)
+
+		emitted := make([]string, len(items))
+		copy(emitted, items)
+		emit(emitted, t.counts[item])
+
+		if len(items) >= maxLen {
+			continue
+		}
+
+		// Build the conditional pattern base from the prefix paths of this item
+		paths := make([][]string, 0, len(t.header[item]))
+		weights := make([]int, 0, len(t.header[item]))
+		for _, node := range t.header[item] {
+			path := make([]string, 0)
+			for parent := node.parent; parent != t.root; parent = parent.parent {
+				path = append(path, parent.item)
+			}
+			if len(path) > 0 {
+				paths = append(paths, path)
+				weights = append(weights, node.count)
+			}
+		}
+
+		conditional := buildFPTree(paths, weights, len(items)+1, frequent)
+		if len(conditional.order) > 0 {
+			conditional.mine(ctx, items, maxLen, frequent, emit)
+		}
+	}
+}
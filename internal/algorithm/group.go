@@ -0,0 +1,97 @@
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// GroupOptions configures MineByGroup
+type GroupOptions struct {
+	Mine  MineOptions
+	Rules RuleOptions
+
+	// Workers is how many groups are mined at the same time. Zero uses GOMAXPROCS.
+	Workers int
+}
+
+// MineByGroup runs the full itemset and rule pipeline on each group's dataset, e.g. one
+// per store or region, and returns the results keyed by group. Groups are mined
+// concurrently by a pool of workers. Each group's rules take exact supports from its
+// own dataset, so opts.Rules.Dataset and opts.Rules.Segment are ignored. A group with
+// no frequent itemsets gets an empty result; any other error stops the run and is
+// returned with the group's name.
+func MineByGroup(groups map[string]*models.Dataset, opts GroupOptions) (map[string]Result, error) {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(names))
+
+	results := make([]Result, len(names))
+	errs := make([]error, len(names))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = mineGroup(names[i], groups[names[i]], opts)
+			}
+		}()
+	}
+	for i := range names {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	byGroup := make(map[string]Result, len(names))
+	for i, name := range names {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("group %q: %w", name, errs[i])
+		}
+		byGroup[name] = results[i]
+	}
+	return byGroup, nil
+}
+
+// mineGroup mines the itemsets and rules of a single group
+func mineGroup(name string, dataset *models.Dataset, opts GroupOptions) (Result, error) {
+	itemsets, err := MineItemsets(dataset, opts.Mine)
+	if err != nil && !errors.Is(err, ErrNoFrequentItemsets) {
+		return Result{}, err
+	}
+
+	ruleOpts := opts.Rules
+	ruleOpts.Dataset = SampledDataset(dataset, opts.Mine)
+	ruleOpts.Segment = nil
+
+	return Result{
+		Dataset: Summarize(dataset, name),
+		Parameters: ResultParameters{
+			MinSupport:     opts.Mine.MinSupport,
+			MinConfidence:  opts.Rules.MinConfidence,
+			MaxLength:      opts.Mine.MaxLength,
+			MinLength:      opts.Mine.MinLength,
+			Algorithm:      opts.Mine.Algorithm,
+			SampleFraction: opts.Mine.SampleFraction,
+			Seed:           opts.Mine.Seed,
+		},
+		Itemsets:  itemsets,
+		Rules:     GenerateRules(itemsets, ruleOpts),
+		CreatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,35 @@
+package algorithm
+
+import (
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// AddSupportIntervals sets SupportLower and SupportUpper on each itemset to the Wilson
+// score interval for its support at the given confidence level (e.g. 0.95)
+func AddSupportIntervals(itemsets []models.FrequentItemset, transactionCount int, level float64) {
+	for i := range itemsets {
+		itemsets[i].SupportLower, itemsets[i].SupportUpper = WilsonInterval(itemsets[i].Count, transactionCount, level)
+	}
+}
+
+// WilsonInterval returns the Wilson score interval for a proportion of count out of total
+func WilsonInterval(count, total int, level float64) (float64, float64) {
+	if total <= 0 {
+		return 0, 0
+	}
+
+	// Two-sided critical value of the standard normal distribution
+	z := math.Sqrt2 * math.Erfinv(level)
+
+	n := float64(total)
+	p := float64(count) / n
+	z2 := z * z
+
+	denominator := 1 + z2/n
+	center := (p + z2/(2*n)) / denominator
+	halfWidth := z * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / denominator
+
+	return math.Max(0, center-halfWidth), math.Min(1, center+halfWidth)
+}
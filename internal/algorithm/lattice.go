@@ -0,0 +1,66 @@
+package algorithm
+
+import (
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// ItemsetLattice is the subset/superset graph over a collection of frequent itemsets.
+// Edges only connect itemsets whose lengths differ by exactly one.
+type ItemsetLattice struct {
+	Nodes    []models.FrequentItemset
+	Children [][]int // Indexes of each node's immediate frequent supersets
+	Parents  [][]int // Indexes of each node's immediate frequent subsets
+
+	index map[string]int
+}
+
+// BuildItemsetLattice links every itemset to its immediate frequent subsets and supersets
+func BuildItemsetLattice(itemsets []models.FrequentItemset) *ItemsetLattice {
+	lattice := &ItemsetLattice{
+		Nodes:    itemsets,
+		Children: make([][]int, len(itemsets)),
+		Parents:  make([][]int, len(itemsets)),
+		index:    make(map[string]int, len(itemsets)),
+	}
+
+	for i, itemset := range itemsets {
+		lattice.index[models.ItemsetKey(itemset.Items)] = i
+	}
+
+	// Each (k-1)-subset obtained by dropping one item is a potential parent
+	for i, itemset := range itemsets {
+		if len(itemset.Items) < 2 {
+			continue
+		}
+
+		items := sortedCopy(itemset.Items)
+		subset := make([]string, len(items)-1)
+		for drop := range items {
+			copy(subset, items[:drop])
+			copy(subset[drop:], items[drop+1:])
+
+			if parent, exists := lattice.index[models.ItemsetKey(subset)]; exists {
+				lattice.Parents[i] = append(lattice.Parents[i], parent)
+				lattice.Children[parent] = append(lattice.Children[parent], i)
+			}
+		}
+	}
+
+	return lattice
+}
+
+// Lookup returns the node index of an itemset, if it is part of the lattice
+func (l *ItemsetLattice) Lookup(items []string) (int, bool) {
+	i, exists := l.index[models.ItemsetKey(items)]
+	return i, exists
+}
+
+// sortedCopy returns a sorted copy of items, leaving the input untouched
+func sortedCopy(items []string) []string {
+	sorted := make([]string, len(items))
+	copy(sorted, items)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,66 @@
+package algorithm
+
+import (
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// FilterMaximal returns the maximal itemsets: those with no frequent proper superset.
+// The input order is preserved.
+func FilterMaximal(itemsets []models.FrequentItemset) []models.FrequentItemset {
+	reported := make(map[string]bool, len(itemsets))
+	for _, itemset := range itemsets {
+		reported[models.ItemsetKey(itemset.Items)] = true
+	}
+
+	// Visit longer itemsets first so every superset has marked its subsets as
+	// covered before the subset itself is checked
+	order := make([]int, len(itemsets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(itemsets[order[a]].Items) > len(itemsets[order[b]].Items)
+	})
+
+	covered := make(map[string]bool)
+	maximal := make([]bool, len(itemsets))
+	for _, i := range order {
+		items := itemsets[i].Items
+		maximal[i] = !covered[models.ItemsetKey(items)]
+		markCovered(items, covered, reported)
+	}
+
+	result := make([]models.FrequentItemset, 0)
+	for i, itemset := range itemsets {
+		if maximal[i] {
+			result = append(result, itemset)
+		}
+	}
+	return result
+}
+
+// markCovered marks the immediate subsets of items as having a frequent superset.
+// Subsets that were not reported themselves (possible with per-length thresholds)
+// are descended into, so their own subsets are still marked.
+func markCovered(items []string, covered, reported map[string]bool) {
+	if len(items) <= 1 {
+		return
+	}
+
+	for skip := range items {
+		subset := make([]string, 0, len(items)-1)
+		subset = append(subset, items[:skip]...)
+		subset = append(subset, items[skip+1:]...)
+
+		key := models.ItemsetKey(subset)
+		if covered[key] {
+			continue
+		}
+		covered[key] = true
+		if !reported[key] {
+			markCovered(subset, covered, reported)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Aggregation decides how the metrics of duplicate rules are combined when merging
+type Aggregation string
+
+const (
+	AggregateMax   Aggregation = "max"
+	AggregateMin   Aggregation = "min"
+	AggregateMean  Aggregation = "mean"
+	AggregateFirst Aggregation = "first"
+)
+
+// ParseAggregation converts an aggregation name to an Aggregation
+func ParseAggregation(name string) (Aggregation, error) {
+	switch agg := Aggregation(name); agg {
+	case AggregateMax, AggregateMin, AggregateMean, AggregateFirst:
+		return agg, nil
+	default:
+		return "", fmt.Errorf("%w: unknown aggregation %q, must be max, min, mean or first", ErrInvalidOption, name)
+	}
+}
+
+// MergeRules unions several rule sets, deduplicating rules with the same antecedent
+// and consequent regardless of item order. The metrics of duplicates are combined
+// one by one with the aggregation, so a max-merged rule may take its confidence and
+// its lift from different inputs. Rules keep the order in which they first appear.
+func MergeRules(ruleSets [][]models.AssociationRule, agg Aggregation) []models.AssociationRule {
+	merged := make([]models.AssociationRule, 0)
+	seen := make(map[string]int)
+	duplicates := make([]int, 0) // Rules merged into each entry, for averaging
+
+	for _, rules := range ruleSets {
+		for _, rule := range rules {
+			key := ruleKey(rule)
+			i, exists := seen[key]
+			if !exists {
+				seen[key] = len(merged)
+				merged = append(merged, rule)
+				duplicates = append(duplicates, 1)
+				continue
+			}
+
+			duplicates[i]++
+			if agg != AggregateFirst {
+				combineRule(&merged[i], rule, agg)
+			}
+		}
+	}
+
+	if agg == AggregateMean {
+		for i := range merged {
+			n := float64(duplicates[i])
+			for _, metric := range ruleMetricFields(&merged[i]) {
+				*metric /= n
+			}
+			merged[i].AntecedentCount = int(math.Round(float64(merged[i].AntecedentCount) / n))
+			merged[i].ItemsetCount = int(math.Round(float64(merged[i].ItemsetCount) / n))
+			merged[i].TransactionCount = int(math.Round(float64(merged[i].TransactionCount) / n))
+		}
+	}
+
+	return merged
+}
+
+// combineRule folds the metrics of a duplicate rule into an accumulated one. For the
+// mean the values are summed here and divided once every duplicate has been seen.
+func combineRule(into *models.AssociationRule, rule models.AssociationRule, agg Aggregation) {
+	combine := func(a, b float64) float64 {
+		switch agg {
+		case AggregateMax:
+			return math.Max(a, b)
+		case AggregateMin:
+			return math.Min(a, b)
+		default:
+			return a + b
+		}
+	}
+	combineCount := func(a, b int) int {
+		return int(combine(float64(a), float64(b)))
+	}
+
+	from := ruleMetricFields(&rule)
+	for i, metric := range ruleMetricFields(into) {
+		*metric = combine(*metric, *from[i])
+	}
+	into.AntecedentCount = combineCount(into.AntecedentCount, rule.AntecedentCount)
+	into.ItemsetCount = combineCount(into.ItemsetCount, rule.ItemsetCount)
+	into.TransactionCount = combineCount(into.TransactionCount, rule.TransactionCount)
+}
+
+// ruleMetricFields returns pointers to the floating-point metrics of a rule
+func ruleMetricFields(rule *models.AssociationRule) []*float64 {
+	return []*float64{&rule.Support, &rule.Confidence, &rule.Lift, &rule.LeverageMetric,
+		&rule.ConvictionMetric, &rule.RevenueScore, &rule.AntecedentSupport, &rule.ConsequentSupport}
+}
@@ -0,0 +1,98 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// ComputeRuleMetrics computes the metrics of an arbitrary rule directly from the dataset,
+// whether or not it would have been mined. It returns an error if the antecedent never
+// appears, since confidence is undefined in that case.
+func ComputeRuleMetrics(antecedent, consequent []string, dataset *models.Dataset) (models.AssociationRule, error) {
+	return computeRuleMetrics(antecedent, consequent, dataset, func(items []string) int {
+		return SupportCount(dataset, items)
+	})
+}
+
+// computeRuleMetrics computes the metrics of a rule with the support counts of count
+func computeRuleMetrics(antecedent, consequent []string, dataset *models.Dataset, count func(items []string) int) (models.AssociationRule, error) {
+	if len(antecedent) == 0 || len(consequent) == 0 {
+		return models.AssociationRule{}, fmt.Errorf("%w: antecedent and consequent must not be empty", ErrInvalidRule)
+	}
+
+	for _, item := range consequent {
+		if containsItem(antecedent, item) {
+			return models.AssociationRule{}, fmt.Errorf("%w: item %q appears in both antecedent and consequent", ErrInvalidRule, item)
+		}
+	}
+
+	if len(dataset.Transactions) == 0 {
+		return models.AssociationRule{}, ErrEmptyDataset
+	}
+
+	itemset := append(append(make([]string, 0, len(antecedent)+len(consequent)), antecedent...), consequent...)
+
+	antecedentCount := count(antecedent)
+	if antecedentCount == 0 {
+		return models.AssociationRule{}, fmt.Errorf("%w: antecedent never appears in the dataset", ErrInvalidRule)
+	}
+	consequentCount := count(consequent)
+	itemsetCount := count(itemset)
+
+	transactionCount := float64(len(dataset.Transactions))
+	rule := newRule(antecedent, consequent,
+		float64(itemsetCount)/transactionCount,
+		float64(antecedentCount)/transactionCount,
+		float64(consequentCount)/transactionCount)
+	rule.AntecedentCount = antecedentCount
+	rule.ItemsetCount = itemsetCount
+	rule.TransactionCount = len(dataset.Transactions)
+
+	return rule, nil
+}
+
+// newRule builds a rule and its metrics from the supports of the itemset and both sides
+func newRule(antecedent, consequent []string, support, antecedentSupport, consequentSupport float64) models.AssociationRule {
+	confidence := support / antecedentSupport
+	lift := confidence / consequentSupport
+	leverage := support - (antecedentSupport * consequentSupport)
+
+	var conviction float64
+	if consequentSupport == 1.0 || confidence == 1.0 {
+		conviction = math.Inf(1)
+	} else {
+		conviction = (1.0 - consequentSupport) / (1.0 - confidence)
+	}
+
+	return models.AssociationRule{
+		Antecedent:        antecedent,
+		Consequent:        consequent,
+		Support:           support,
+		Confidence:        confidence,
+		Lift:              lift,
+		LeverageMetric:    leverage,
+		ConvictionMetric:  conviction,
+		AntecedentSupport: antecedentSupport,
+		ConsequentSupport: consequentSupport,
+	}
+}
+
+// ReverseRuleMetrics holds the confidence and lift of the reverse B => A of a rule
+// A => B. Lift is symmetric, so only the confidence can differ from the rule's.
+type ReverseRuleMetrics struct {
+	Confidence float64
+	Lift       float64
+}
+
+// ReverseMetrics computes the metrics of the reverse of a rule from the support of its
+// consequent without generating the reverse rule. It reports false when supports
+// knows nothing about the consequent or its support is zero.
+func ReverseMetrics(rule models.AssociationRule, supports *SupportResolver) (ReverseRuleMetrics, bool) {
+	consequentSupport, ok := supports.Support(rule.Consequent)
+	if !ok || consequentSupport == 0 {
+		return ReverseRuleMetrics{}, false
+	}
+	return ReverseRuleMetrics{Confidence: rule.Support / consequentSupport, Lift: rule.Lift}, true
+}
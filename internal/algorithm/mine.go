@@ -0,0 +1,48 @@
+package algorithm
+
+import (
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Mine runs the whole pipeline in one call: it mines the frequent itemsets of the
+// dataset with opts, generates rules from them with opts.Rules and returns both with
+// the mining statistics and parameters. The rules take exact supports missing from the
+// itemsets from the mined dataset, or from its sample with opts.SampleFraction, unless
+// opts.Rules.Dataset names another. It returns the errors of MineItemsets, including ErrNoFrequentItemsets.
+func Mine(dataset *models.Dataset, opts MineOptions) (*Result, error) {
+	itemsets, stats, err := MineItemsetsWithStats(dataset, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleOpts := opts.Rules
+	if ruleOpts.Dataset == nil {
+		ruleOpts.Dataset = SampledDataset(dataset, opts)
+	}
+
+	// Record the per-length thresholds as evaluated for each length searched
+	var lengthSupport []float64
+	if opts.LengthSupport != nil {
+		lengthSupport = thresholdsFor(opts).report[1:]
+	}
+
+	return &Result{
+		Dataset: Summarize(dataset, ""),
+		Parameters: ResultParameters{
+			MinSupport:     opts.MinSupport,
+			LengthSupport:  lengthSupport,
+			MinConfidence:  ruleOpts.MinConfidence,
+			MaxLength:      opts.MaxLength,
+			MinLength:      opts.MinLength,
+			Algorithm:      opts.Algorithm,
+			SampleFraction: opts.SampleFraction,
+			Seed:           opts.Seed,
+		},
+		Itemsets:  itemsets,
+		Rules:     GenerateRules(itemsets, ruleOpts),
+		Stats:     stats,
+		CreatedAt: time.Now(),
+	}, nil
+}
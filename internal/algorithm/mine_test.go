@@ -0,0 +1,71 @@
+package algorithm
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+func TestMineRecordsLengthSupport(t *testing.T) {
+	dataset := models.NewDataset([]models.Transaction{
+		{"a", "b", "c"}, {"a", "b"}, {"a", "c"}, {"b", "c"},
+	})
+
+	result, err := Mine(dataset, MineOptions{MaxLength: 3, LengthSupport: WithLengthSupport([]float64{0.5, 0.25})})
+	if err != nil {
+		t.Fatalf("Mine: %v", err)
+	}
+	if want := []float64{0.5, 0.25, 0.25}; !reflect.DeepEqual(result.Parameters.LengthSupport, want) {
+		t.Errorf("LengthSupport = %v, want %v", result.Parameters.LengthSupport, want)
+	}
+
+	result, err = Mine(dataset, MineOptions{MinSupport: 0.5, MaxLength: 3})
+	if err != nil {
+		t.Fatalf("Mine: %v", err)
+	}
+	if result.Parameters.LengthSupport != nil {
+		t.Errorf("LengthSupport = %v without per-length supports, want nil", result.Parameters.LengthSupport)
+	}
+}
+
+func TestSampledRulesCountSampledTransactions(t *testing.T) {
+	transactions := make([]models.Transaction, 200)
+	for i := range transactions {
+		switch i % 4 {
+		case 0:
+			transactions[i] = models.Transaction{"a", "b", "c"}
+		case 1:
+			transactions[i] = models.Transaction{"a", "b"}
+		case 2:
+			transactions[i] = models.Transaction{"a", "c"}
+		default:
+			transactions[i] = models.Transaction{"b"}
+		}
+	}
+	dataset := models.NewDataset(transactions)
+
+	for name, opts := range map[string]MineOptions{
+		"MinSupport":    {MinSupport: 0.1, MaxLength: 3, SampleFraction: 0.5, Seed: 3},
+		"LengthSupport": {MaxLength: 3, LengthSupport: WithLengthSupport([]float64{0.9, 0.1}), SampleFraction: 0.5, Seed: 3},
+	} {
+		opts.Rules = RuleOptions{MinConfidence: 0.3}
+		result, err := Mine(dataset, opts)
+		if err != nil {
+			t.Fatalf("%s: Mine: %v", name, err)
+		}
+		if len(result.Rules) == 0 {
+			t.Fatalf("%s: no rules to check", name)
+		}
+		sampled := len(SampledDataset(dataset, opts).Transactions)
+		for _, rule := range result.Rules {
+			if rule.TransactionCount != sampled {
+				t.Errorf("%s: rule %v => %v counts %d transactions, want the %d sampled", name, rule.Antecedent, rule.Consequent, rule.TransactionCount, sampled)
+			}
+			if got := float64(rule.TransactionCount) * rule.Support; math.Abs(got-float64(rule.ItemsetCount)) > 1e-9 {
+				t.Errorf("%s: rule %v => %v has TransactionCount * Support = %v, want ItemsetCount %d", name, rule.Antecedent, rule.Consequent, got, rule.ItemsetCount)
+			}
+		}
+	}
+}
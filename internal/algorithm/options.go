@@ -0,0 +1,182 @@
+package algorithm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Algorithm identifies an itemset mining strategy
+type Algorithm string
+
+const (
+	// AlgorithmAuto picks a strategy per run based on the dataset and options
+	AlgorithmAuto Algorithm = ""
+	// AlgorithmApriori is the classic level-wise candidate generation approach
+	AlgorithmApriori Algorithm = "apriori"
+	// AlgorithmEclat mines depth-first over transaction ID sets (tidsets)
+	AlgorithmEclat Algorithm = "eclat"
+	// AlgorithmFPGrowth mines a compressed prefix tree without candidate generation
+	AlgorithmFPGrowth Algorithm = "fpgrowth"
+)
+
+// ParseAlgorithm converts an algorithm name into an Algorithm
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch algorithm := Algorithm(strings.ToLower(strings.TrimSpace(name))); algorithm {
+	case AlgorithmApriori, AlgorithmEclat, AlgorithmFPGrowth:
+		return algorithm, nil
+	case "auto":
+		return AlgorithmAuto, nil
+	default:
+		return "", fmt.Errorf("%w: unknown algorithm %q", ErrInvalidOption, name)
+	}
+}
+
+// MineOptions configures a frequent itemset mining run
+type MineOptions struct {
+	MinSupport float64
+	MaxLength  int
+	Algorithm  Algorithm
+
+	// MinLength drops itemsets shorter than it from the result, e.g. MinLength equal
+	// to MaxLength returns only itemsets of that exact length. The shorter levels are
+	// still mined internally, as the longer ones are built from them. Zero keeps all.
+	MinLength int
+
+	// MemoryBudgetMB is an approximate memory budget; when the estimated Apriori
+	// candidate memory exceeds it, AlgorithmAuto falls back to Eclat. Zero disables it.
+	MemoryBudgetMB int
+
+	// ConfidenceLevel, when between 0 and 1 (e.g. 0.95), fills in a Wilson score
+	// interval around each itemset's support. Zero disables it.
+	ConfidenceLevel float64
+
+	// LengthSupport, when set, overrides MinSupport with a threshold per itemset
+	// length k, e.g. 1% for pairs but 0.2% for triples. Lower thresholds at longer
+	// lengths are honoured by keeping the shorter itemsets they depend on in the search.
+	LengthSupport func(k int) float64
+
+	// SampleFraction, when between 0 and 1, mines a random sample of that fraction
+	// of the transactions instead of the full dataset. Zero disables sampling.
+	SampleFraction float64
+
+	// Seed initialises the random source used for sampling. Sampling draws from a
+	// local source created from Seed before any mining starts and never from the
+	// global math/rand, so two runs with the same Seed and input select the same
+	// transactions and produce identical results, regardless of worker scheduling.
+	Seed int64
+
+	// SinglePass makes Apriori count all levels in one scan of the transactions, each
+	// transaction enumerating its subsets of frequent items up to MaxLength, instead
+	// of rescanning per level. The supports are identical; it pays off for many levels
+	// over medium-sized baskets and costs combinatorial memory for very large ones.
+	// Per-level statistics are not recorded in this mode.
+	SinglePass bool
+
+	// MinAllConfidence drops itemsets whose all-confidence, their support divided by the
+	// highest support of any one of their items, is below it. This keeps the itemsets
+	// whose items occur together (hyperclique patterns) and drops those that are
+	// frequent only because one member is ubiquitous. Zero disables it.
+	MinAllConfidence float64
+
+	// Weights, when set, measures support as the share of the total transaction weight
+	// rather than of the transaction count, e.g. RecencyWeight to favour recent baskets.
+	// Weighted mining always uses Eclat; Count stays the unweighted transaction count.
+	Weights TransactionWeights
+
+	// Observer, when set, is notified at the start and end of the itemset phase
+	Observer models.Observer
+
+	// Rules configures the rule phase of Mine and is ignored by the functions that
+	// only mine itemsets
+	Rules RuleOptions
+}
+
+// WithLengthSupport returns a per-length support function that uses thresholds[k-1]
+// for length k and the last threshold for any longer length
+func WithLengthSupport(thresholds []float64) func(k int) float64 {
+	return func(k int) float64 {
+		if k-1 < len(thresholds) {
+			return thresholds[k-1]
+		}
+		return thresholds[len(thresholds)-1]
+	}
+}
+
+// validate checks that the options describe a runnable mining job
+func (opts MineOptions) validate() error {
+	if opts.MinSupport < 0 || opts.MinSupport > 1 {
+		return fmt.Errorf("%w %v: must be between 0 and 1", ErrInvalidSupport, opts.MinSupport)
+	}
+	if opts.MaxLength < 1 {
+		return fmt.Errorf("%w: max length %d must be at least 1", ErrInvalidOption, opts.MaxLength)
+	}
+	if opts.MinLength < 0 || opts.MinLength > opts.MaxLength {
+		return fmt.Errorf("%w: min length %d must be between 0 and the max length %d", ErrInvalidOption, opts.MinLength, opts.MaxLength)
+	}
+	if err := thresholdsFor(opts).validate(); err != nil {
+		return err
+	}
+	if opts.ConfidenceLevel < 0 || opts.ConfidenceLevel >= 1 {
+		return fmt.Errorf("%w: confidence level %v must be in [0, 1)", ErrInvalidOption, opts.ConfidenceLevel)
+	}
+	if opts.MinAllConfidence < 0 || opts.MinAllConfidence > 1 {
+		return fmt.Errorf("%w: min all-confidence %v must be between 0 and 1", ErrInvalidOption, opts.MinAllConfidence)
+	}
+	if opts.SampleFraction < 0 || opts.SampleFraction > 1 {
+		return fmt.Errorf("%w: sample fraction %v must be between 0 and 1", ErrInvalidOption, opts.SampleFraction)
+	}
+	if opts.MemoryBudgetMB < 0 {
+		return fmt.Errorf("%w: memory budget %d must not be negative", ErrInvalidOption, opts.MemoryBudgetMB)
+	}
+	switch opts.Algorithm {
+	case AlgorithmAuto, AlgorithmApriori, AlgorithmEclat, AlgorithmFPGrowth:
+	default:
+		return fmt.Errorf("%w: unknown algorithm %q", ErrInvalidOption, opts.Algorithm)
+	}
+	return nil
+}
+
+// RuleOptions configures association rule generation
+type RuleOptions struct {
+	MinConfidence float64
+
+	// Prices maps items to unit prices. When set, every rule gets a RevenueScore:
+	// the expected extra consequent revenue per basket containing the antecedent,
+	// (confidence - consequent support) * total consequent price.
+	Prices map[string]float64
+
+	// MaximalOnly generates rules only from maximal itemsets, giving fewer and more
+	// general rules. Supports are still looked up among all itemsets, so metrics
+	// stay correct even though the non-maximal subsets produce no rules of their own.
+	MaximalOnly bool
+
+	// MaxAntecedentLength caps the number of items in a rule's antecedent, independent
+	// of the itemset length. Zero means no limit.
+	MaxAntecedentLength int
+
+	// Dataset, when set, supplies exact supports for antecedents and consequents that
+	// are missing from the itemsets, e.g. because a per-length threshold pruned them.
+	// Without it, rules needing a missing support are skipped.
+	Dataset *models.Dataset
+
+	// PerfectRuleMinCount drops rules with a confidence of 1 whose antecedent appears in
+	// fewer transactions than this. Such rules are often an artifact of a rare antecedent
+	// rather than a real implication. Zero keeps them all.
+	PerfectRuleMinCount int
+
+	// Segment marks a subpopulation of Dataset's transactions, e.g. from SegmentMask.
+	// When set, each rule also gets its confidence and lift within the segment while
+	// support stays computed over all transactions. It needs Dataset and one entry per
+	// transaction and is ignored otherwise.
+	Segment []bool
+
+	// AllowedConsequents, when not nil, keeps only rules whose consequent items are all
+	// in the set, e.g. the items a campaign can promote. Itemsets without any allowed
+	// item are skipped before their antecedents are enumerated.
+	AllowedConsequents map[string]bool
+
+	// Observer, when set, is notified at the start and end of the rule phase
+	Observer models.Observer
+}
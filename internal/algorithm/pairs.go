@@ -0,0 +1,51 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// GeneratePairRules generates both directed rules a => b and b => a for every frequent
+// 2-itemset, taking confidences straight from the single-item supports instead of
+// enumerating subsets. Longer itemsets are ignored.
+func GeneratePairRules(itemsets []models.FrequentItemset, minConfidence float64) []models.AssociationRule {
+	itemsets = models.CanonicalItemsets(itemsets)
+
+	singles := make(map[string]models.FrequentItemset)
+	for _, itemset := range itemsets {
+		if len(itemset.Items) == 1 {
+			singles[itemset.Items[0]] = itemset
+		}
+	}
+	transactionCount := transactionTotal(itemsets)
+
+	rules := make([]models.AssociationRule, 0)
+	for _, pair := range itemsets {
+		if len(pair.Items) != 2 || pair.Items[0] == pair.Items[1] {
+			continue
+		}
+
+		first, okFirst := singles[pair.Items[0]]
+		second, okSecond := singles[pair.Items[1]]
+		if !okFirst || !okSecond {
+			continue // Should not happen with a complete set of frequent itemsets
+		}
+
+		for _, direction := range [][2]models.FrequentItemset{{first, second}, {second, first}} {
+			antecedent, consequent := direction[0], direction[1]
+			if antecedent.Support == 0 || consequent.Support == 0 {
+				continue // Confidence or lift would be undefined
+			}
+			if pair.Support/antecedent.Support < minConfidence {
+				continue
+			}
+
+			rule := newRule([]string{antecedent.Items[0]}, []string{consequent.Items[0]}, pair.Support, antecedent.Support, consequent.Support)
+			rule.AntecedentCount = antecedent.Count
+			rule.ItemsetCount = pair.Count
+			rule.TransactionCount = transactionCount
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
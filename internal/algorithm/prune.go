@@ -0,0 +1,105 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// PruneInfrequentItems returns a copy of the dataset restricted to items that meet
+// minSupport on their own, along with the number of items that were removed.
+// Transactions left empty are kept so supports are still computed over the full
+// transaction count.
+func PruneInfrequentItems(dataset *models.Dataset, minSupport float64) (*models.Dataset, int) {
+	transactionCount := float64(len(dataset.Transactions))
+	counts := itemCounts(dataset)
+
+	keep := make(map[string]bool, len(dataset.UniqueItems))
+	uniqueItems := make([]string, 0, len(dataset.UniqueItems))
+	for _, item := range dataset.UniqueItems {
+		if float64(counts[item])/transactionCount >= minSupport {
+			keep[item] = true
+			uniqueItems = append(uniqueItems, item)
+		}
+	}
+
+	pruned := &models.Dataset{
+		Transactions: pruneTransactions(dataset.Transactions, keep),
+		UniqueItems:  uniqueItems,
+		ItemsMap:     keep,
+		Timestamps:   dataset.Timestamps,
+		Quantities:   dataset.Quantities,
+		Sequences:    dataset.Sequences,
+	}
+
+	return pruned, len(dataset.UniqueItems) - len(uniqueItems)
+}
+
+// CountInfrequentItems returns how many of the dataset's items are below the lowest
+// support threshold of any length in opts, so no itemset mined with opts can contain
+// them. Supports are weighted when opts.Weights is set. Unlike PruneInfrequentItems it
+// only counts, without copying the transactions.
+func CountInfrequentItems(dataset *models.Dataset, opts MineOptions) (int, error) {
+	if len(dataset.Transactions) == 0 {
+		return 0, nil
+	}
+	threshold := thresholdsFor(opts).survive[1]
+
+	supports := make(map[string]float64, len(dataset.UniqueItems))
+	if opts.Weights != nil {
+		weights, err := opts.Weights(dataset)
+		if err == nil {
+			err = validateWeights(weights, len(dataset.Transactions))
+		}
+		if err != nil {
+			return 0, err
+		}
+		total := 0.0
+		for _, weight := range weights {
+			total += weight
+		}
+		for i, transaction := range dataset.Transactions {
+			for _, item := range transaction {
+				supports[item] += weights[i]
+			}
+		}
+		for item := range supports {
+			supports[item] /= total
+		}
+	} else {
+		transactionCount := float64(len(dataset.Transactions))
+		for item, count := range itemCounts(dataset) {
+			supports[item] = float64(count) / transactionCount
+		}
+	}
+
+	infrequent := 0
+	for _, item := range dataset.UniqueItems {
+		if supports[item] < threshold {
+			infrequent++
+		}
+	}
+	return infrequent, nil
+}
+
+// frequentItemSet collects the items of frequent 1-itemsets into a lookup set
+func frequentItemSet(L1 []models.FrequentItemset) map[string]bool {
+	keep := make(map[string]bool, len(L1))
+	for _, itemset := range L1 {
+		keep[itemset.Items[0]] = true
+	}
+	return keep
+}
+
+// pruneTransactions removes items not in keep from every transaction
+func pruneTransactions(transactions []models.Transaction, keep map[string]bool) []models.Transaction {
+	pruned := make([]models.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		filtered := make(models.Transaction, 0, len(transaction))
+		for _, item := range transaction {
+			if keep[item] {
+				filtered = append(filtered, item)
+			}
+		}
+		pruned[i] = filtered
+	}
+	return pruned
+}
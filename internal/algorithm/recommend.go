@@ -0,0 +1,64 @@
+package algorithm
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Recommendation is an item suggested for a basket, with the rule that suggests it
+// most strongly
+type Recommendation struct {
+	Item       string
+	Confidence float64
+	Lift       float64
+	Rule       models.AssociationRule
+}
+
+// Recommend suggests up to n items for a basket, or all when n is zero or less, from
+// the rules whose antecedent is contained in the basket. Each consequent item not
+// already in the basket is scored by the most confident such rule, and ties are broken
+// by lift and then by item name.
+func Recommend(rules []models.AssociationRule, basket []string, n int) []Recommendation {
+	inBasket := make(map[string]bool, len(basket))
+	for _, item := range basket {
+		inBasket[item] = true
+	}
+
+	best := make(map[string]Recommendation)
+	for _, rule := range rules {
+		if !allWanted(rule.Antecedent, inBasket) {
+			continue
+		}
+		for _, item := range rule.Consequent {
+			if inBasket[item] {
+				continue
+			}
+			current, seen := best[item]
+			if !seen || rule.Confidence > current.Confidence ||
+				(rule.Confidence == current.Confidence && rule.Lift > current.Lift) {
+				best[item] = Recommendation{Item: item, Confidence: rule.Confidence, Lift: rule.Lift, Rule: rule}
+			}
+		}
+	}
+
+	recommendations := make([]Recommendation, 0, len(best))
+	for _, recommendation := range best {
+		recommendations = append(recommendations, recommendation)
+	}
+	slices.SortFunc(recommendations, func(a, b Recommendation) int {
+		if c := cmp.Compare(b.Confidence, a.Confidence); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Lift, a.Lift); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Item, b.Item)
+	})
+
+	if n > 0 && len(recommendations) > n {
+		recommendations = recommendations[:n]
+	}
+	return recommendations
+}
@@ -0,0 +1,106 @@
+package algorithm
+
+import (
+	"sync"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SupportResolver looks up itemset supports, answering from mined itemsets when
+// possible and otherwise counting the itemset exactly in the dataset and caching the
+// result. It is safe for concurrent use.
+type SupportResolver struct {
+	dataset *models.Dataset
+	mined   map[string]models.FrequentItemset // Never modified after construction
+	total   int
+
+	mu     sync.Mutex
+	cached map[string]int // Itemsets counted in the dataset
+}
+
+// NewSupportResolver creates a resolver over mined itemsets. The dataset may be nil,
+// in which case itemsets that were not mined are reported as unknown.
+func NewSupportResolver(itemsets []models.FrequentItemset, dataset *models.Dataset) *SupportResolver {
+	resolver := &SupportResolver{
+		dataset: dataset,
+		mined:   make(map[string]models.FrequentItemset, len(itemsets)),
+		total:   transactionTotal(itemsets),
+		cached:  make(map[string]int),
+	}
+	if dataset != nil {
+		resolver.total = len(dataset.Transactions)
+	}
+
+	for _, itemset := range itemsets {
+		resolver.mined[models.ItemsetKey(itemset.Items)] = itemset
+	}
+	return resolver
+}
+
+// Count returns the number of transactions containing the items, and whether that
+// number is known
+func (r *SupportResolver) Count(items []string) (int, bool) {
+	key := models.ItemsetKey(items)
+	if itemset, ok := r.mined[key]; ok {
+		return itemset.Count, true
+	}
+	if r.dataset == nil {
+		return 0, false
+	}
+	return r.countInDataset(key, items), true
+}
+
+// countInDataset counts an itemset in the dataset, caching the result by key
+func (r *SupportResolver) countInDataset(key string, items []string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count, ok := r.cached[key]
+	if !ok {
+		count = SupportCount(r.dataset, items)
+		r.cached[key] = count
+	}
+	return count
+}
+
+// Support returns the fraction of transactions containing the items, and whether it
+// is known. Mined itemsets report their stored support.
+func (r *SupportResolver) Support(items []string) (float64, bool) {
+	key := models.ItemsetKey(items)
+	if itemset, ok := r.mined[key]; ok {
+		return itemset.Support, true
+	}
+	if r.dataset == nil || r.total == 0 {
+		return 0, false
+	}
+	return float64(r.countInDataset(key, items)) / float64(r.total), true
+}
+
+// TransactionCount returns the number of transactions supports are relative to
+func (r *SupportResolver) TransactionCount() int {
+	return r.total
+}
+
+// MissingSubsets counts the distinct proper subsets of the itemsets that are not among
+// them. Rules need the support of their antecedent and consequent, so without
+// RuleOptions.Dataset a missing subset skips the rules it would be a side of. An
+// itemsets file from a plain run has none missing, whatever its maximum length, as
+// every subset of a frequent itemset is frequent and no longer; files filtered to
+// closed or maximal itemsets or by a minimum length do.
+func MissingSubsets(itemsets []models.FrequentItemset) int {
+	known := make(map[string]bool, len(itemsets))
+	for _, itemset := range itemsets {
+		known[models.ItemsetKey(itemset.Items)] = true
+	}
+
+	missing := make(map[string]bool)
+	for _, itemset := range itemsets {
+		full := 1<<uint(len(itemset.Items)) - 1
+		for mask := 1; mask < full; mask++ {
+			key := models.ItemsetKey(maskItems(itemset.Items, mask))
+			if !known[key] {
+				missing[key] = true
+			}
+		}
+	}
+	return len(missing)
+}
@@ -0,0 +1,79 @@
+package algorithm
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Result bundles a complete analysis: what was mined, the parameters used and the
+// resulting itemsets and rules
+type Result struct {
+	Dataset    DatasetSummary
+	Parameters ResultParameters
+	Itemsets   []models.FrequentItemset
+	Rules      []models.AssociationRule
+	Stats      MiningStats // Per-level Apriori statistics, if recorded
+	CreatedAt  time.Time
+}
+
+// DatasetSummary describes the dataset a result was mined from
+type DatasetSummary struct {
+	Source           string // File or other origin of the data, if known
+	TransactionCount int
+	ItemCount        int
+}
+
+// ResultParameters records the thresholds a result was produced with
+type ResultParameters struct {
+	MinSupport     float64
+	LengthSupport  []float64 // Per-length minimum supports, if used instead of MinSupport
+	MinConfidence  float64
+	MaxLength      int
+	MinLength      int
+	Algorithm      Algorithm
+	SampleFraction float64
+	Seed           int64
+}
+
+// Summarize describes a dataset for a Result
+func Summarize(dataset *models.Dataset, source string) DatasetSummary {
+	return DatasetSummary{
+		Source:           source,
+		TransactionCount: len(dataset.Transactions),
+		ItemCount:        len(dataset.UniqueItems),
+	}
+}
+
+// Save writes the result to a file in gob format, which keeps every float exactly,
+// including infinite convictions
+func (r *Result) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating result file: %w", err)
+	}
+	defer file.Close()
+
+	if err := gob.NewEncoder(file).Encode(r); err != nil {
+		return fmt.Errorf("error encoding result: %w", err)
+	}
+	return file.Close()
+}
+
+// LoadResult reads a result written by Save
+func LoadResult(path string) (*Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening result file: %w", err)
+	}
+	defer file.Close()
+
+	var result Result
+	if err := gob.NewDecoder(file).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding result: %w", err)
+	}
+	return &result, nil
+}
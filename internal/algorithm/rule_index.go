@@ -0,0 +1,31 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// RuleIndex maps antecedents to their rules for constant-time lookup when serving
+// recommendations
+type RuleIndex struct {
+	rules map[string][]models.AssociationRule
+}
+
+// BuildRuleIndex buckets rules by antecedent. Rules keep their relative order within a bucket.
+func BuildRuleIndex(rules []models.AssociationRule) RuleIndex {
+	index := RuleIndex{rules: make(map[string][]models.AssociationRule)}
+	for _, rule := range rules {
+		key := models.ItemsetKey(rule.Antecedent)
+		index.rules[key] = append(index.rules[key], rule)
+	}
+	return index
+}
+
+// Lookup returns the rules whose antecedent is exactly the given items, in any order
+func (index RuleIndex) Lookup(antecedent []string) []models.AssociationRule {
+	return index.rules[models.ItemsetKey(antecedent)]
+}
+
+// Len returns the number of distinct antecedents in the index
+func (index RuleIndex) Len() int {
+	return len(index.rules)
+}
@@ -0,0 +1,86 @@
+package algorithm
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SampleTransactions returns a dataset keeping each transaction independently with
+// probability fraction. All randomness comes from rng, so the same source state
+// always selects the same transactions.
+func SampleTransactions(dataset *models.Dataset, fraction float64, rng *rand.Rand) *models.Dataset {
+	indices := make([]int, 0, int(float64(len(dataset.Transactions))*fraction)+1)
+	for i := range dataset.Transactions {
+		if rng.Float64() < fraction {
+			indices = append(indices, i)
+		}
+	}
+	return subset(dataset, indices)
+}
+
+// SampledDataset returns the transactions mining with opts runs on: the sample that
+// opts.SampleFraction and opts.Seed select, or the dataset itself without sampling.
+// Rules for the mined itemsets need it as RuleOptions.Dataset so that supports looked
+// up later count the same transactions.
+func SampledDataset(dataset *models.Dataset, opts MineOptions) *models.Dataset {
+	if opts.SampleFraction > 0 && opts.SampleFraction < 1 {
+		return SampleTransactions(dataset, opts.SampleFraction, rand.New(rand.NewSource(opts.Seed)))
+	}
+	return dataset
+}
+
+// ReservoirSample returns a dataset of n transactions chosen uniformly at random
+// (or every transaction if there are fewer than n), drawing only from rng
+func ReservoirSample(dataset *models.Dataset, n int, rng *rand.Rand) *models.Dataset {
+	if n >= len(dataset.Transactions) {
+		indices := make([]int, len(dataset.Transactions))
+		for i := range indices {
+			indices[i] = i
+		}
+		return subset(dataset, indices)
+	}
+
+	reservoir := make([]int, n)
+	for i := range reservoir {
+		reservoir[i] = i
+	}
+	for i := n; i < len(dataset.Transactions); i++ {
+		if j := rng.Intn(i + 1); j < n {
+			reservoir[j] = i
+		}
+	}
+
+	return subset(dataset, reservoir)
+}
+
+// subset builds a dataset from the transactions at the given indices, carrying their
+// timestamps along when the dataset has them
+func subset(dataset *models.Dataset, indices []int) *models.Dataset {
+	transactions := make([]models.Transaction, len(indices))
+	for i, index := range indices {
+		transactions[i] = dataset.Transactions[index]
+	}
+
+	sampled := models.NewDataset(transactions)
+	if dataset.Timestamps != nil {
+		sampled.Timestamps = make([]time.Time, len(indices))
+		for i, index := range indices {
+			sampled.Timestamps[i] = dataset.Timestamps[index]
+		}
+	}
+	if dataset.Quantities != nil {
+		sampled.Quantities = make([]map[string]int, len(indices))
+		for i, index := range indices {
+			sampled.Quantities[i] = dataset.Quantities[index]
+		}
+	}
+	if dataset.Sequences != nil {
+		sampled.Sequences = make([][]string, len(indices))
+		for i, index := range indices {
+			sampled.Sequences[i] = dataset.Sequences[index]
+		}
+	}
+	return sampled
+}
@@ -0,0 +1,70 @@
+package algorithm
+
+import (
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// ScoreWeights weights the metrics ScoreRules combines. A zero weight leaves its metric
+// out of the score.
+type ScoreWeights struct {
+	Confidence float64
+	Lift       float64
+	Support    float64
+	Revenue    float64 // Weight of RevenueScore, set when rules are generated with prices
+}
+
+// ScoreRules sets the Score of every rule to a weighted sum of its confidence, lift,
+// support and revenue score. Each metric is first min-max normalized to [0, 1] over the
+// whole rule set, so metrics of different scales can be weighted against each other
+// and a rule's score depends on the rules it is ranked with. An infinite value counts
+// as the maximum, NaN as the minimum, and a metric that is the same for every rule
+// adds nothing. Sort by MetricScore to rank the rules by their score.
+func ScoreRules(rules []models.AssociationRule, weights ScoreWeights) {
+	metrics := []struct {
+		weight float64
+		value  func(models.AssociationRule) float64
+	}{
+		{weights.Confidence, func(rule models.AssociationRule) float64 { return rule.Confidence }},
+		{weights.Lift, func(rule models.AssociationRule) float64 { return rule.Lift }},
+		{weights.Support, func(rule models.AssociationRule) float64 { return rule.Support }},
+		{weights.Revenue, func(rule models.AssociationRule) float64 { return rule.RevenueScore }},
+	}
+
+	for i := range rules {
+		rules[i].Score = 0
+	}
+	for _, metric := range metrics {
+		if metric.weight == 0 {
+			continue
+		}
+
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, rule := range rules {
+			if value := metric.value(rule); !math.IsInf(value, 0) && !math.IsNaN(value) {
+				low = math.Min(low, value)
+				high = math.Max(high, value)
+			}
+		}
+		if !(high > low) {
+			continue
+		}
+
+		for i := range rules {
+			rules[i].Score += metric.weight * normalize(metric.value(rules[i]), low, high)
+		}
+	}
+}
+
+// normalize maps a value into [0, 1] relative to the finite range low..high
+func normalize(value, low, high float64) float64 {
+	switch {
+	case math.IsNaN(value), math.IsInf(value, -1):
+		return 0
+	case math.IsInf(value, 1):
+		return 1
+	default:
+		return (value - low) / (high - low)
+	}
+}
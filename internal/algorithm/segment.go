@@ -0,0 +1,73 @@
+package algorithm
+
+import (
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SegmentMask marks the transactions of a dataset that belong to a subpopulation,
+// e.g. weekend baskets picked out by their Timestamps, for RuleOptions.Segment
+func SegmentMask(dataset *models.Dataset, keep func(index int, transaction models.Transaction) bool) []bool {
+	mask := make([]bool, len(dataset.Transactions))
+	for i, transaction := range dataset.Transactions {
+		mask[i] = keep(i, transaction)
+	}
+	return mask
+}
+
+// segmentCounter counts itemsets among the transactions of a segment, caching counts
+// by itemset key
+type segmentCounter struct {
+	dataset *models.Dataset
+	mask    []bool
+	size    int
+	cached  map[string]int
+}
+
+// newSegmentCounter creates a counter for a segment mask, or returns nil when the mask
+// does not describe the dataset's transactions
+func newSegmentCounter(dataset *models.Dataset, mask []bool) *segmentCounter {
+	if dataset == nil || len(mask) != len(dataset.Transactions) {
+		return nil
+	}
+
+	size := 0
+	for _, member := range mask {
+		if member {
+			size++
+		}
+	}
+	return &segmentCounter{dataset: dataset, mask: mask, size: size, cached: make(map[string]int)}
+}
+
+// count returns the number of segment transactions containing every one of the items
+func (c *segmentCounter) count(items []string) int {
+	key := models.ItemsetKey(items)
+	if count, ok := c.cached[key]; ok {
+		return count
+	}
+
+	count := 0
+	for _, tid := range transactionIDs(c.dataset, items) {
+		if c.mask[tid] {
+			count++
+		}
+	}
+	c.cached[key] = count
+	return count
+}
+
+// apply sets the segment-conditional confidence and lift of a rule. Metrics that are
+// undefined because the segment lacks the antecedent or consequent are set to NaN.
+func (c *segmentCounter) apply(rule *models.AssociationRule, itemset []string) {
+	antecedentCount := c.count(rule.Antecedent)
+	consequentCount := c.count(rule.Consequent)
+	if antecedentCount == 0 || consequentCount == 0 {
+		rule.SegmentConfidence, rule.SegmentLift = math.NaN(), math.NaN()
+		return
+	}
+
+	rule.SegmentConfidence = float64(c.count(itemset)) / float64(antecedentCount)
+	rule.SegmentLift = rule.SegmentConfidence / (float64(consequentCount) / float64(c.size))
+}
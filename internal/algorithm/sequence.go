@@ -0,0 +1,85 @@
+package algorithm
+
+import (
+	"fmt"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// GenerateSequentialPairRules finds ordered rules A => B between single items, where a
+// transaction supports the rule only when A occurs before B in it, e.g. a page viewed
+// before another in a clickstream. It reads the dataset's Sequences, so the data must
+// be loaded with LoadOptions.KeepOrder.
+//
+// The support of A => B is the fraction of transactions with an A before a B, and
+// its confidence that support divided by the support of A, the share of transactions
+// with A in which a B follows. Lift and the other metrics compare against the plain
+// support of B. Unlike unordered rules, A => B and B => A usually differ in support.
+// Only pairs of items that each reach minSupport are considered, and rules are
+// returned in the order of SortRules by confidence.
+func GenerateSequentialPairRules(dataset *models.Dataset, minSupport, minConfidence float64) ([]models.AssociationRule, error) {
+	if minSupport < 0 || minSupport > 1 {
+		return nil, fmt.Errorf("%w %v: must be between 0 and 1", ErrInvalidSupport, minSupport)
+	}
+	if len(dataset.Transactions) == 0 {
+		return nil, ErrEmptyDataset
+	}
+	if len(dataset.Sequences) != len(dataset.Transactions) {
+		return nil, fmt.Errorf("%w: sequential rules need the event order of every transaction; load with KeepOrder", ErrInvalidOption)
+	}
+
+	transactionCount := len(dataset.Transactions)
+	counts := itemCounts(dataset)
+	frequent := make(map[string]bool)
+	for item, count := range counts {
+		if float64(count)/float64(transactionCount) >= minSupport {
+			frequent[item] = true
+		}
+	}
+
+	// A precedes B somewhere in a sequence exactly when A's first occurrence comes
+	// before B's last one
+	pairCounts := make(map[[2]string]int)
+	for _, sequence := range dataset.Sequences {
+		first := make(map[string]int)
+		last := make(map[string]int)
+		for position, item := range sequence {
+			if !frequent[item] {
+				continue
+			}
+			if _, seen := first[item]; !seen {
+				first[item] = position
+			}
+			last[item] = position
+		}
+		for a, firstA := range first {
+			for b, lastB := range last {
+				if a != b && firstA < lastB {
+					pairCounts[[2]string{a, b}]++
+				}
+			}
+		}
+	}
+
+	rules := make([]models.AssociationRule, 0)
+	for pair, count := range pairCounts {
+		support := float64(count) / float64(transactionCount)
+		if support < minSupport {
+			continue
+		}
+
+		antecedentSupport := float64(counts[pair[0]]) / float64(transactionCount)
+		consequentSupport := float64(counts[pair[1]]) / float64(transactionCount)
+		rule := newRule([]string{pair[0]}, []string{pair[1]}, support, antecedentSupport, consequentSupport)
+		if rule.Confidence < minConfidence {
+			continue
+		}
+		rule.AntecedentCount = counts[pair[0]]
+		rule.ItemsetCount = count
+		rule.TransactionCount = transactionCount
+		rules = append(rules, rule)
+	}
+
+	SortRules(rules, MetricConfidence)
+	return rules, nil
+}
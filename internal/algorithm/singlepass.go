@@ -0,0 +1,84 @@
+package algorithm
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// cancelCheckInterval is how many transactions are enumerated between checks for
+// cancellation
+const cancelCheckInterval = 1024
+
+// findFrequentItemsetsSinglePass finds the same itemsets as findFrequentItemsets but
+// counts every level in one scan: after the single items are counted, each transaction
+// enumerates its subsets of surviving items up to maxLen and increments their counts.
+// This replaces one scan per level with one scan in total, at the cost of a number of
+// subsets that grows combinatorially with the number of surviving items per basket.
+func findFrequentItemsetsSinglePass(ctx context.Context, dataset *models.Dataset, maxLen int, thresholds levelThresholds) []models.FrequentItemset {
+	transactionCount := float64(len(dataset.Transactions))
+
+	// Find the single items that can be part of a reported itemset
+	counts := itemCounts(dataset)
+	keep := make(map[string]bool)
+	for item, count := range counts {
+		if thresholds.survives(1, float64(count)/transactionCount) {
+			keep[item] = true
+		}
+	}
+
+	// Count every subset of length 2..maxLen of each transaction in one scan
+	subsetCounts := make(map[string]int)
+	subset := make([]string, 0, maxLen)
+	var enumerate func(items []string)
+	enumerate = func(items []string) {
+		for i, item := range items {
+			subset = append(subset, item)
+			if len(subset) >= 2 {
+				subsetCounts[models.ItemsetKey(subset)]++
+			}
+			if len(subset) < maxLen {
+				enumerate(items[i+1:])
+			}
+			subset = subset[:len(subset)-1]
+		}
+	}
+	for i, transaction := range pruneTransactions(dataset.Transactions, keep) {
+		if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil
+		}
+		sort.Strings(transaction)
+		enumerate(transaction)
+	}
+
+	// Every subset of an itemset has at least its support, so a reported itemset's
+	// subsets all survive and the exact counts give the level-wise result directly
+	result := make([]models.FrequentItemset, 0)
+	for item := range keep {
+		result = appendIfReported(result, []string{item}, counts[item], transactionCount, thresholds)
+	}
+	for key, count := range subsetCounts {
+		result = appendIfReported(result, strings.Split(key, keyDelimiter), count, transactionCount, thresholds)
+	}
+
+	sortItemsetsCanonical(result)
+	return result
+}
+
+// appendIfReported appends an itemset with the given count when it meets the
+// reporting threshold of its length
+func appendIfReported(result []models.FrequentItemset, items []string, count int, transactionCount float64,
+	thresholds levelThresholds) []models.FrequentItemset {
+	support := float64(count) / transactionCount
+	if !thresholds.reports(len(items), support) {
+		return result
+	}
+	return append(result, models.FrequentItemset{
+		Items:   items,
+		Support: support,
+		Length:  len(items),
+		Count:   count,
+	})
+}
@@ -0,0 +1,47 @@
+package algorithm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+func TestSinglePassMatchesApriori(t *testing.T) {
+	dataset := models.NewDataset([]models.Transaction{
+		{"a", "b", "c", "d"}, {"a", "b", "c"}, {"a", "b", "d"}, {"a", "c", "d"},
+		{"b", "c", "d"}, {"a", "b"}, {"c", "d"}, {"a", "b", "c", "d", "e"},
+		{"a", "e"}, {"b", "e"},
+	})
+
+	tests := []struct {
+		name string
+		opts MineOptions
+	}{
+		{"MinSupport", MineOptions{MinSupport: 0.2, MaxLength: 4}},
+		{"LengthSupport", MineOptions{MaxLength: 4, LengthSupport: WithLengthSupport([]float64{0.5, 0.3, 0.1})}},
+		{"MinLengthEqualsMaxLength", MineOptions{MinSupport: 0.2, MinLength: 3, MaxLength: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levelwise, _, err := MineItemsetsPartial(context.Background(), dataset, tt.opts)
+			if err != nil {
+				t.Fatalf("MineItemsetsPartial: %v", err)
+			}
+			opts := tt.opts
+			opts.SinglePass = true
+			singlePass, _, err := MineItemsetsPartial(context.Background(), dataset, opts)
+			if err != nil {
+				t.Fatalf("MineItemsetsPartial with SinglePass: %v", err)
+			}
+
+			if len(levelwise) == 0 {
+				t.Fatal("found no itemsets to compare")
+			}
+			if !reflect.DeepEqual(singlePass, levelwise) {
+				t.Errorf("single pass found\n%+v\nwant\n%+v", singlePass, levelwise)
+			}
+		})
+	}
+}
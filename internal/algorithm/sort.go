@@ -0,0 +1,93 @@
+package algorithm
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Metric names a rule metric that rules can be ranked by
+type Metric string
+
+const (
+	MetricSupport    Metric = "support"
+	MetricConfidence Metric = "confidence"
+	MetricLift       Metric = "lift"
+	MetricLeverage   Metric = "leverage"
+	MetricConviction Metric = "conviction"
+	MetricScore      Metric = "score" // Set by ScoreRules
+)
+
+// ParseMetric converts a metric name to a Metric
+func ParseMetric(name string) (Metric, error) {
+	switch metric := Metric(name); metric {
+	case MetricSupport, MetricConfidence, MetricLift, MetricLeverage, MetricConviction, MetricScore:
+		return metric, nil
+	default:
+		return "", fmt.Errorf("%w: unknown metric %q, must be support, confidence, lift, leverage, conviction or score", ErrInvalidOption, name)
+	}
+}
+
+// value returns the metric of a rule
+func (m Metric) value(rule models.AssociationRule) float64 {
+	switch m {
+	case MetricSupport:
+		return rule.Support
+	case MetricLift:
+		return rule.Lift
+	case MetricLeverage:
+		return rule.LeverageMetric
+	case MetricConviction:
+		return rule.ConvictionMetric
+	case MetricScore:
+		return rule.Score
+	default:
+		return rule.Confidence
+	}
+}
+
+// SortRules sorts rules in place from best to worst. Rules are ranked by the first
+// metric, highest first, with ties broken by each following metric in turn and finally
+// by the antecedent and consequent items in lexicographic order, so the order never
+// depends on the input order. Confidence is used when no metric is given.
+func SortRules(rules []models.AssociationRule, by ...Metric) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return compareRules(rules[i], rules[j], by) < 0
+	})
+}
+
+// TopKRules returns the k best rules in the order of SortRules, without modifying the
+// input. All rules are returned when there are at most k.
+func TopKRules(rules []models.AssociationRule, k int, by ...Metric) []models.AssociationRule {
+	sorted := make([]models.AssociationRule, len(rules))
+	copy(sorted, rules)
+	SortRules(sorted, by...)
+
+	if k >= 0 && k < len(sorted) {
+		sorted = sorted[:k]
+	}
+	return sorted
+}
+
+// compareRules returns a negative number when a ranks before b, a positive number when
+// it ranks after b and zero when neither metrics nor items tell them apart
+func compareRules(a, b models.AssociationRule, by []Metric) int {
+	if len(by) == 0 {
+		by = []Metric{MetricConfidence}
+	}
+	for _, metric := range by {
+		if va, vb := metric.value(a), metric.value(b); va != vb {
+			if va > vb {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	if c := slices.Compare(a.Antecedent, b.Antecedent); c != 0 {
+		return c
+	}
+	return slices.Compare(a.Consequent, b.Consequent)
+}
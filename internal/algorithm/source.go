@@ -0,0 +1,139 @@
+package algorithm
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// MineSource finds frequent itemsets with Apriori while reading the transactions from
+// a source instead of a Dataset, so only the item counts and candidates are held in
+// memory. The source is reset and read once per itemset length: one pass for the
+// single items and one more for every length up to MaxLength that still has
+// candidates, so a disk-backed source reads its whole file up to MaxLength times.
+// Algorithm and MemoryBudgetMB are ignored, and Weights and SampleFraction, which need
+// the transactions in memory, are rejected. Errors are those of MineItemsets and of
+// the source.
+func MineSource(source models.TransactionSource, opts MineOptions) ([]models.FrequentItemset, error) {
+	defer models.StartPhase(opts.Observer, models.PhaseItemsets)()
+
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if opts.Weights != nil || (opts.SampleFraction > 0 && opts.SampleFraction < 1) {
+		return nil, fmt.Errorf("%w: weights and sampling cannot be used when mining a transaction source", ErrInvalidOption)
+	}
+	thresholds := thresholdsFor(opts)
+
+	// Count the transactions and single items in the first pass
+	transactionCount := 0
+	counts := make(map[string]int)
+	err := scanSource(source, func(transaction []string) {
+		transactionCount++
+		for _, item := range transaction {
+			counts[item]++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if transactionCount == 0 {
+		return nil, ErrEmptyDataset
+	}
+	total := float64(transactionCount)
+
+	items := make([]string, 0, len(counts))
+	for item := range counts {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+
+	L1 := make([]models.FrequentItemset, 0)
+	for _, item := range items {
+		support := float64(counts[item]) / total
+		if thresholds.survives(1, support) {
+			L1 = append(L1, models.FrequentItemset{Items: []string{item}, Support: support, Length: 1, Count: counts[item]})
+		}
+	}
+	result := appendReported(make([]models.FrequentItemset, 0), L1, thresholds)
+	keep := frequentItemSet(L1)
+
+	// Count each longer level's candidates in one more pass
+	Lk_1 := L1
+	for k := 2; k <= opts.MaxLength && len(Lk_1) > 0; k++ {
+		Ck := generateCandidates(Lk_1, k)
+		if len(Ck) == 0 {
+			break
+		}
+
+		candidateCounts := make([]int, len(Ck))
+		err := scanSource(source, func(transaction []string) {
+			pruned := make(models.Transaction, 0, len(transaction))
+			for _, item := range transaction {
+				if keep[item] {
+					pruned = append(pruned, item)
+				}
+			}
+			if len(pruned) < k {
+				return
+			}
+			for i, candidate := range Ck {
+				if isSubset(candidate.Items, pruned) {
+					candidateCounts[i]++
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		Lk := make([]models.FrequentItemset, 0)
+		for i, candidate := range Ck {
+			support := float64(candidateCounts[i]) / total
+			if thresholds.survives(k, support) {
+				Lk = append(Lk, models.FrequentItemset{Items: candidate.Items, Support: support, Length: k, Count: candidateCounts[i]})
+			}
+		}
+		result = appendReported(result, Lk, thresholds)
+		Lk_1 = Lk
+	}
+
+	if opts.MinAllConfidence > 0 {
+		supports := make(map[string]float64, len(counts))
+		for item, count := range counts {
+			supports[item] = float64(count) / total
+		}
+		result = filterAllConfidence(result, opts.MinAllConfidence, supports)
+	}
+	sortItemsetsCanonical(result)
+	if opts.ConfidenceLevel > 0 {
+		AddSupportIntervals(result, transactionCount, opts.ConfidenceLevel)
+	}
+	if len(result) == 0 {
+		return result, ErrNoFrequentItemsets
+	}
+	return result, nil
+}
+
+// scanSource makes one pass over a source, passing each transaction to visit as a
+// sorted copy without repeated items
+func scanSource(source models.TransactionSource, visit func(transaction []string)) error {
+	if err := source.Reset(); err != nil {
+		return fmt.Errorf("error resetting transaction source: %w", err)
+	}
+	for {
+		items, ok := source.Next()
+		if !ok {
+			break
+		}
+		transaction := slices.Clone(items)
+		slices.Sort(transaction)
+		visit(slices.Compact(transaction))
+	}
+	if err := source.Err(); err != nil {
+		return fmt.Errorf("error reading transaction source: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package algorithm
+
+import (
+	"context"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// aprioriCandidateBytes approximates the memory held by one 2-itemset candidate
+// (struct, slice header and two string headers plus allocator overhead)
+const aprioriCandidateBytes = 96
+
+// MineItemsets finds frequent itemsets using the strategy selected by the options. It
+// returns the empty result together with ErrNoFrequentItemsets when nothing is frequent.
+// Itemsets come back in a canonical order, by length and then items, whichever algorithm
+// ran and however its work was scheduled, so the same input always gives the same output.
+func MineItemsets(dataset *models.Dataset, opts MineOptions) ([]models.FrequentItemset, error) {
+	return mineItemsets(context.Background(), dataset, opts, nil, false)
+}
+
+// MineItemsetsContext mines like MineItemsets but gives up when ctx is cancelled or
+// its deadline passes, returning ctx.Err() and no itemsets
+func MineItemsetsContext(ctx context.Context, dataset *models.Dataset, opts MineOptions) ([]models.FrequentItemset, error) {
+	return mineItemsets(ctx, dataset, opts, nil, false)
+}
+
+// MineItemsetsWithStats mines like MineItemsets, with the same errors, and also returns per-level statistics.
+// Only Apriori works level by level, so the level statistics are empty for other algorithms.
+func MineItemsetsWithStats(dataset *models.Dataset, opts MineOptions) ([]models.FrequentItemset, MiningStats, error) {
+	var stats MiningStats
+	itemsets, err := mineItemsets(context.Background(), dataset, opts, &stats, false)
+	return itemsets, stats, err
+}
+
+// MineItemsetsPartial mines like MineItemsetsWithStats but, when ctx is cancelled,
+// returns the itemsets found so far along with ctx.Err() instead of none, e.g. to save
+// progress when a long run is interrupted. Apriori keeps every itemset of the lengths
+// it completed; Eclat and FP-Growth keep the itemsets they reached, each with its exact
+// support, and the single-pass mode has none until its scan is over.
+func MineItemsetsPartial(ctx context.Context, dataset *models.Dataset, opts MineOptions) ([]models.FrequentItemset, MiningStats, error) {
+	var stats MiningStats
+	itemsets, err := mineItemsets(ctx, dataset, opts, &stats, true)
+	return itemsets, stats, err
+}
+
+// mineItemsets runs the selected strategy, filling stats when it is not nil. When ctx
+// is cancelled it returns ctx.Err() with the itemsets found so far if partial is set,
+// and with none otherwise.
+func mineItemsets(ctx context.Context, dataset *models.Dataset, opts MineOptions, stats *MiningStats, partial bool) ([]models.FrequentItemset, error) {
+	defer models.StartPhase(opts.Observer, models.PhaseItemsets)()
+
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if len(dataset.Transactions) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	dataset = SampledDataset(dataset, opts)
+	thresholds := thresholdsFor(opts)
+
+	var itemsets []models.FrequentItemset
+	var weights []float64
+	if opts.Weights != nil {
+		var err error
+		weights, err = opts.Weights(dataset)
+		if err == nil {
+			err = validateWeights(weights, len(dataset.Transactions))
+		}
+		if err != nil {
+			return nil, err
+		}
+		itemsets = findWeightedItemsetsEclat(ctx, dataset, weights, opts.MaxLength, thresholds)
+		if stats != nil {
+			stats.Algorithm = AlgorithmEclat
+		}
+	} else {
+		algorithm, estimate := chooseAlgorithm(dataset, opts)
+		if stats != nil {
+			stats.Algorithm, stats.EstimatedAprioriMB = algorithm, estimate
+		}
+		itemsets = mineWith(ctx, algorithm, dataset, opts, thresholds, stats)
+	}
+	cancelled := ctx.Err()
+	if cancelled != nil && !partial {
+		return nil, cancelled
+	}
+
+	if opts.MinAllConfidence > 0 {
+		itemsets = filterAllConfidence(itemsets, opts.MinAllConfidence, itemSupports(dataset, weights))
+	}
+	sortItemsetsCanonical(itemsets)
+
+	if opts.ConfidenceLevel > 0 {
+		AddSupportIntervals(itemsets, len(dataset.Transactions), opts.ConfidenceLevel)
+	}
+
+	if cancelled != nil {
+		return itemsets, cancelled
+	}
+	if len(itemsets) == 0 {
+		return itemsets, ErrNoFrequentItemsets
+	}
+
+	return itemsets, nil
+}
+
+// mineWith runs one unweighted mining algorithm
+func mineWith(ctx context.Context, algorithm Algorithm, dataset *models.Dataset, opts MineOptions, thresholds levelThresholds, stats *MiningStats) []models.FrequentItemset {
+	switch {
+	case algorithm == AlgorithmEclat:
+		return findFrequentItemsetsEclat(ctx, dataset, opts.MaxLength, thresholds)
+	case algorithm == AlgorithmFPGrowth:
+		return findFrequentItemsetsFPGrowth(ctx, dataset, opts.MaxLength, thresholds)
+	case opts.SinglePass:
+		return findFrequentItemsetsSinglePass(ctx, dataset, opts.MaxLength, thresholds)
+	default:
+		return findFrequentItemsets(ctx, dataset, opts.MaxLength, thresholds, stats)
+	}
+}
+
+// ChooseAlgorithm decides which mining strategy to use for a run. The mining functions
+// record the choice, and the estimate it was made from, in MiningStats.
+func ChooseAlgorithm(dataset *models.Dataset, opts MineOptions) Algorithm {
+	algorithm, _ := chooseAlgorithm(dataset, opts)
+	return algorithm
+}
+
+// chooseAlgorithm decides like ChooseAlgorithm and also returns the Apriori memory
+// estimate compared with the budget, or 0 when none was needed
+func chooseAlgorithm(dataset *models.Dataset, opts MineOptions) (Algorithm, float64) {
+	if opts.Algorithm != AlgorithmAuto {
+		return opts.Algorithm, 0
+	}
+
+	if opts.MemoryBudgetMB <= 0 {
+		return AlgorithmApriori, 0
+	}
+
+	// The 1-itemsets kept in the search determine the number of pair candidates
+	estimate := EstimateAprioriMemoryMB(dataset, thresholdsFor(opts).survive[1])
+	if estimate > float64(opts.MemoryBudgetMB) {
+		return AlgorithmEclat, estimate
+	}
+	return AlgorithmApriori, estimate
+}
+
+// EstimateAprioriMemoryMB estimates the memory needed for the 2-itemset candidates,
+// which is where Apriori's candidate set is usually largest
+func EstimateAprioriMemoryMB(dataset *models.Dataset, minSupport float64) float64 {
+	transactionCount := float64(len(dataset.Transactions))
+	if transactionCount == 0 {
+		return 0
+	}
+
+	frequent := 0
+	for _, count := range itemCounts(dataset) {
+		if float64(count)/transactionCount >= minSupport {
+			frequent++
+		}
+	}
+
+	candidates := float64(frequent) * float64(frequent-1) / 2
+	return candidates * aprioriCandidateBytes / (1024 * 1024)
+}
@@ -0,0 +1,138 @@
+package algorithm
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// largeDataset builds a dataset big enough for item counting to run in parallel
+func largeDataset() *models.Dataset {
+	random := rand.New(rand.NewSource(1))
+	transactions := make([]models.Transaction, parallelCountMinTransactions+5000)
+	for i := range transactions {
+		seen := make(map[string]bool)
+		for j := 0; j < 2+random.Intn(5); j++ {
+			// Skew the item frequencies so mining finds itemsets of several lengths
+			item := fmt.Sprintf("item%02d", int(30*random.Float64()*random.Float64()))
+			if !seen[item] {
+				seen[item] = true
+				transactions[i] = append(transactions[i], item)
+			}
+		}
+	}
+	return models.NewDataset(transactions)
+}
+
+func TestMineItemsetsParallelDeterministic(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	dataset := largeDataset()
+
+	for _, algorithm := range []Algorithm{AlgorithmApriori, AlgorithmEclat, AlgorithmFPGrowth} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			opts := MineOptions{MinSupport: 0.02, MaxLength: 3, Algorithm: algorithm}
+			var first []models.FrequentItemset
+			for run := 0; run < 10; run++ {
+				itemsets, err := MineItemsets(dataset, opts)
+				if err != nil {
+					t.Fatalf("MineItemsets: %v", err)
+				}
+				if run == 0 {
+					first = itemsets
+					assertCanonicalOrder(t, itemsets)
+					if itemsets[len(itemsets)-1].Length != opts.MaxLength {
+						t.Fatalf("found no itemsets of length %d to compare", opts.MaxLength)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(itemsets, first) {
+					t.Fatalf("run %d returned different itemsets than run 0", run)
+				}
+			}
+		})
+	}
+}
+
+func TestMineByGroupDeterministic(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	dataset := largeDataset()
+	half := len(dataset.Transactions) / 2
+	groups := map[string]*models.Dataset{
+		"north": models.NewDataset(dataset.Transactions[:half]),
+		"south": models.NewDataset(dataset.Transactions[half:]),
+		"all":   dataset,
+	}
+	opts := GroupOptions{
+		Mine:    MineOptions{MinSupport: 0.02, MaxLength: 3},
+		Rules:   RuleOptions{MinConfidence: 0.1},
+		Workers: 3,
+	}
+
+	first, err := MineByGroup(groups, opts)
+	if err != nil {
+		t.Fatalf("MineByGroup: %v", err)
+	}
+	for run := 1; run < 5; run++ {
+		results, err := MineByGroup(groups, opts)
+		if err != nil {
+			t.Fatalf("MineByGroup: %v", err)
+		}
+		for name, result := range results {
+			assertCanonicalOrder(t, result.Itemsets)
+			if !reflect.DeepEqual(result.Itemsets, first[name].Itemsets) || !reflect.DeepEqual(result.Rules, first[name].Rules) {
+				t.Fatalf("run %d returned a different result for group %q than run 0", run, name)
+			}
+		}
+	}
+}
+
+// assertCanonicalOrder checks that itemsets are ordered by length and then by their
+// ItemsetKey
+func assertCanonicalOrder(t *testing.T, itemsets []models.FrequentItemset) {
+	t.Helper()
+	for i := 1; i < len(itemsets); i++ {
+		a, b := itemsets[i-1], itemsets[i]
+		if a.Length > b.Length || (a.Length == b.Length && models.ItemsetKey(a.Items) >= models.ItemsetKey(b.Items)) {
+			t.Fatalf("itemsets %v and %v are out of canonical order", a.Items, b.Items)
+		}
+	}
+}
+
+func TestMiningStatsRecordAutomaticChoice(t *testing.T) {
+	// 200 items in every basket make about 1.8 MB of pair candidates
+	items := make(models.Transaction, 200)
+	for i := range items {
+		items[i] = fmt.Sprintf("item%03d", i)
+	}
+	dataset := models.NewDataset([]models.Transaction{items, items})
+
+	tests := []struct {
+		name     string
+		opts     MineOptions
+		want     Algorithm
+		estimate bool
+	}{
+		{"over budget", MineOptions{MinSupport: 0.5, MaxLength: 1, MemoryBudgetMB: 1}, AlgorithmEclat, true},
+		{"within budget", MineOptions{MinSupport: 0.5, MaxLength: 1, MemoryBudgetMB: 2}, AlgorithmApriori, true},
+		{"no budget", MineOptions{MinSupport: 0.5, MaxLength: 1}, AlgorithmApriori, false},
+		{"explicit", MineOptions{MinSupport: 0.5, MaxLength: 1, MemoryBudgetMB: 1, Algorithm: AlgorithmFPGrowth}, AlgorithmFPGrowth, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stats, err := MineItemsetsWithStats(dataset, tt.opts)
+			if err != nil {
+				t.Fatalf("MineItemsetsWithStats: %v", err)
+			}
+			if stats.Algorithm != tt.want {
+				t.Errorf("Algorithm = %s, want %s", stats.Algorithm, tt.want)
+			}
+			if got := stats.EstimatedAprioriMB > 0; got != tt.estimate {
+				t.Errorf("EstimatedAprioriMB = %v, want an estimate: %v", stats.EstimatedAprioriMB, tt.estimate)
+			}
+		})
+	}
+}
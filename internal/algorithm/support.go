@@ -0,0 +1,131 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SupportCount counts the transactions that contain every one of the given items by
+// intersecting their lists in the dataset's inverted index
+func SupportCount(dataset *models.Dataset, items []string) int {
+	if len(items) == 0 {
+		return len(dataset.Transactions)
+	}
+	return len(transactionIDs(dataset, items))
+}
+
+// transactionIDs returns the sorted indices of the transactions containing every one of
+// the given items, which must not be empty
+func transactionIDs(dataset *models.Dataset, items []string) []int {
+	index := dataset.InvertedIndex()
+	tids := index[items[0]]
+	for _, item := range items[1:] {
+		if len(tids) == 0 {
+			break
+		}
+		tids = intersectSorted(tids, index[item])
+	}
+	return tids
+}
+
+// Support returns the fraction of transactions that contain every one of the given items
+func Support(dataset *models.Dataset, items []string) float64 {
+	if len(dataset.Transactions) == 0 {
+		return 0
+	}
+	return float64(SupportCount(dataset, items)) / float64(len(dataset.Transactions))
+}
+
+// SupportToKeep returns the largest minimum support at which mining still reports the
+// itemset, its exact support, so any threshold at or below it keeps the itemset as long
+// as the maximum length allows. It is zero for an itemset that never occurs.
+func SupportToKeep(dataset *models.Dataset, items []string) float64 {
+	return Support(dataset, items)
+}
+
+// RuleThresholds are the largest thresholds at which a rule is still generated
+type RuleThresholds struct {
+	MinSupport    float64
+	MinConfidence float64
+}
+
+// ThresholdsToKeep returns the largest minimum support and confidence at which the rule
+// antecedent => consequent is still mined from the dataset: the support of the whole
+// itemset and the rule's confidence. It returns the errors of ComputeRuleMetrics,
+// including for an antecedent that never occurs.
+func ThresholdsToKeep(dataset *models.Dataset, antecedent, consequent []string) (RuleThresholds, error) {
+	rule, err := ComputeRuleMetrics(antecedent, consequent, dataset)
+	if err != nil {
+		return RuleThresholds{}, err
+	}
+	return RuleThresholds{MinSupport: rule.Support, MinConfidence: rule.Confidence}, nil
+}
+
+// QuantitySupport is the quantity-based counterpart of Support for datasets that keep
+// multiplicities: each transaction containing the items contributes the number of
+// complete sets of them it holds, the smallest quantity among the items, e.g. 2 for a
+// basket with 3 milk and 2 bread when asking for {milk, bread}. The sum is divided by
+// the transaction count, so it is the mean number of sets per basket and can exceed 1.
+// Without Quantities it equals Support.
+func QuantitySupport(dataset *models.Dataset, items []string) float64 {
+	if len(dataset.Transactions) == 0 {
+		return 0
+	}
+	if len(items) == 0 {
+		return 1
+	}
+
+	sets := 0
+	for _, tid := range transactionIDs(dataset, items) {
+		smallest := dataset.Quantity(tid, items[0])
+		for _, item := range items[1:] {
+			smallest = min(smallest, dataset.Quantity(tid, item))
+		}
+		sets += smallest
+	}
+	return float64(sets) / float64(len(dataset.Transactions))
+}
+
+// VerifySupports recounts the exact support of each itemset over the full dataset in a
+// single pass, replacing the reported values (e.g. from sampled mining) and dropping
+// itemsets whose verified support falls below minSupport
+func VerifySupports(itemsets []models.FrequentItemset, dataset *models.Dataset, minSupport float64) []models.FrequentItemset {
+	counts := make([]int, len(itemsets))
+
+	for _, transaction := range dataset.Transactions {
+		present := make(map[string]bool, len(transaction))
+		for _, item := range transaction {
+			present[item] = true
+		}
+
+		for i, itemset := range itemsets {
+			contained := true
+			for _, item := range itemset.Items {
+				if !present[item] {
+					contained = false
+					break
+				}
+			}
+			if contained {
+				counts[i]++
+			}
+		}
+	}
+
+	transactionCount := float64(len(dataset.Transactions))
+	verified := make([]models.FrequentItemset, 0, len(itemsets))
+	for i, itemset := range itemsets {
+		support := 0.0
+		if transactionCount > 0 {
+			support = float64(counts[i]) / transactionCount
+		}
+		if support < minSupport {
+			continue
+		}
+
+		itemset.Support = support
+		itemset.Count = counts[i]
+		verified = append(verified, itemset)
+	}
+
+	return verified
+}
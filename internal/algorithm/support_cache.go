@@ -0,0 +1,104 @@
+package algorithm
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SupportCache memoizes the support counts of itemsets in a dataset, so repeated queries
+// for the same itemset, in any item order, skip the index intersection. It is safe for
+// concurrent use. The dataset must not change while the cache is in use.
+type SupportCache struct {
+	dataset  *models.Dataset
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	recency *list.List // Most recently used at the front
+	hits    int
+	misses  int
+}
+
+// cachedCount is the value of a cache entry
+type cachedCount struct {
+	key   string
+	count int
+}
+
+// NewSupportCache creates a cache over a dataset that holds up to capacity itemsets,
+// evicting the least recently used one when full. A capacity of zero or less never
+// evicts.
+func NewSupportCache(dataset *models.Dataset, capacity int) *SupportCache {
+	return &SupportCache{
+		dataset:  dataset,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		recency:  list.New(),
+	}
+}
+
+// Count returns the number of transactions containing every one of the items, like
+// SupportCount
+func (c *SupportCache) Count(items []string) int {
+	key := models.ItemsetKey(items)
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.recency.MoveToFront(element)
+		c.hits++
+		count := element.Value.(*cachedCount).count
+		c.mu.Unlock()
+		return count
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Count without holding the lock, so misses for different itemsets run in parallel;
+	// two goroutines missing on the same itemset both count it and store the same value
+	count := SupportCount(c.dataset, items)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.recency.MoveToFront(element)
+		return count
+	}
+	c.entries[key] = c.recency.PushFront(&cachedCount{key: key, count: count})
+	if c.capacity > 0 && c.recency.Len() > c.capacity {
+		oldest := c.recency.Back()
+		c.recency.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedCount).key)
+	}
+	return count
+}
+
+// Support returns the fraction of transactions containing every one of the items, like
+// Support
+func (c *SupportCache) Support(items []string) float64 {
+	if len(c.dataset.Transactions) == 0 {
+		return 0
+	}
+	return float64(c.Count(items)) / float64(len(c.dataset.Transactions))
+}
+
+// RuleMetrics computes the metrics of a rule like ComputeRuleMetrics, taking the
+// supports of the antecedent, consequent and whole itemset from the cache
+func (c *SupportCache) RuleMetrics(antecedent, consequent []string) (models.AssociationRule, error) {
+	return computeRuleMetrics(antecedent, consequent, c.dataset, c.Count)
+}
+
+// Len returns the number of cached itemsets
+func (c *SupportCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recency.Len()
+}
+
+// Stats returns how many lookups were answered from the cache and how many were counted
+func (c *SupportCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
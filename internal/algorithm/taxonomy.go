@@ -0,0 +1,37 @@
+package algorithm
+
+import (
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// FilterCrossCategoryRules drops rules whose antecedent and consequent items all
+// belong to the same parent category, such as "whole milk => skim milk", which are
+// usually substitutions rather than cross-sells. Rules with any uncategorized item are kept.
+func FilterCrossCategoryRules(rules []models.AssociationRule, taxonomy models.Taxonomy) []models.AssociationRule {
+	filtered := make([]models.AssociationRule, 0, len(rules))
+	for _, rule := range rules {
+		if !WithinCategory(rule, taxonomy) {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
+
+// WithinCategory reports whether every item of a rule has the same known category
+func WithinCategory(rule models.AssociationRule, taxonomy models.Taxonomy) bool {
+	category := ""
+	for _, side := range [][]string{rule.Antecedent, rule.Consequent} {
+		for _, item := range side {
+			parent, ok := taxonomy[item]
+			if !ok || parent == "" {
+				return false
+			}
+			if category == "" {
+				category = parent
+			} else if parent != category {
+				return false
+			}
+		}
+	}
+	return category != ""
+}
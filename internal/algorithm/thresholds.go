@@ -0,0 +1,78 @@
+package algorithm
+
+import (
+	"fmt"
+)
+
+// levelThresholds holds the support thresholds applied at each itemset length.
+// report[k] decides whether a k-itemset is returned, while survive[k] is the lowest
+// threshold of any level from k up to the maximum length: a k-itemset below it can
+// have no reportable superset and is dropped from the search. Index 0 is unused.
+// Itemsets shorter than minLength are searched but never reported.
+type levelThresholds struct {
+	report    []float64
+	survive   []float64
+	minLength int
+}
+
+// constantThresholds applies the same minimum support at every length
+func constantThresholds(minSupport float64, maxLen int) levelThresholds {
+	return newLevelThresholds(func(int) float64 { return minSupport }, maxLen)
+}
+
+// newLevelThresholds builds thresholds for lengths 1..maxLen from a per-length function
+func newLevelThresholds(supportFor func(k int) float64, maxLen int) levelThresholds {
+	// Single items are always counted, even when maxLen is below 1
+	maxLen = max(maxLen, 1)
+
+	thresholds := levelThresholds{
+		report:  make([]float64, maxLen+1),
+		survive: make([]float64, maxLen+1),
+	}
+
+	for k := 1; k <= maxLen; k++ {
+		thresholds.report[k] = supportFor(k)
+	}
+
+	// Carry the lowest threshold down from the longest level
+	for k := maxLen; k >= 1; k-- {
+		thresholds.survive[k] = thresholds.report[k]
+		if k < maxLen && thresholds.survive[k+1] < thresholds.survive[k] {
+			thresholds.survive[k] = thresholds.survive[k+1]
+		}
+	}
+
+	return thresholds
+}
+
+// thresholdsFor builds the level thresholds described by mining options
+func thresholdsFor(opts MineOptions) levelThresholds {
+	var thresholds levelThresholds
+	if opts.LengthSupport != nil {
+		thresholds = newLevelThresholds(opts.LengthSupport, opts.MaxLength)
+	} else {
+		thresholds = constantThresholds(opts.MinSupport, opts.MaxLength)
+	}
+	thresholds.minLength = opts.MinLength
+	return thresholds
+}
+
+// validate checks that every threshold is a valid support fraction
+func (t levelThresholds) validate() error {
+	for k := 1; k < len(t.report); k++ {
+		if t.report[k] < 0 || t.report[k] > 1 {
+			return fmt.Errorf("%w %v for length %d: must be between 0 and 1", ErrInvalidSupport, t.report[k], k)
+		}
+	}
+	return nil
+}
+
+// reports reports whether a k-itemset with the given support belongs in the result
+func (t levelThresholds) reports(k int, support float64) bool {
+	return k >= t.minLength && support >= t.report[k]
+}
+
+// survives reports whether a k-itemset with the given support may have reportable supersets
+func (t levelThresholds) survives(k int, support float64) bool {
+	return support >= t.survive[k]
+}
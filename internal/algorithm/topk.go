@@ -0,0 +1,90 @@
+package algorithm
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// TopKCollector keeps the k best rules out of a stream of rules, in the order of
+// SortRules, holding at most k rules at a time. Its Add method can be passed directly
+// to StreamRules.
+type TopKCollector struct {
+	k    int
+	seen int
+	heap rankedRules
+}
+
+// rankedRule is a collected rule with its arrival order
+type rankedRule struct {
+	rule models.AssociationRule
+	seq  int
+}
+
+// rankedRules is a min-heap with the weakest rule on top
+type rankedRules struct {
+	entries []rankedRule
+	by      []Metric
+}
+
+func (h rankedRules) Len() int           { return len(h.entries) }
+func (h rankedRules) Less(i, j int) bool { return h.weaker(h.entries[i], h.entries[j]) }
+func (h rankedRules) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *rankedRules) Push(x any)        { h.entries = append(h.entries, x.(rankedRule)) }
+func (h *rankedRules) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// weaker reports whether a ranks below b. Of two rules that compareRules cannot tell
+// apart the later one is weaker, so ties resolve like the stable SortRules.
+func (h rankedRules) weaker(a, b rankedRule) bool {
+	if c := compareRules(a.rule, b.rule, h.by); c != 0 {
+		return c > 0
+	}
+	return a.seq > b.seq
+}
+
+// NewTopKCollector creates a collector for the k best rules by the given metrics,
+// compared as in SortRules
+func NewTopKCollector(k int, by ...Metric) *TopKCollector {
+	return &TopKCollector{k: k, heap: rankedRules{entries: make([]rankedRule, 0, max(k, 0)), by: by}}
+}
+
+// Add offers a rule to the collector, evicting the weakest kept rule when more than
+// k would be held. It always returns true so that generation continues.
+func (c *TopKCollector) Add(rule models.AssociationRule) bool {
+	entry := rankedRule{rule: rule, seq: c.seen}
+	c.seen++
+	if c.k <= 0 {
+		return true
+	}
+
+	if c.heap.Len() < c.k {
+		heap.Push(&c.heap, entry)
+	} else if c.heap.weaker(c.heap.entries[0], entry) {
+		c.heap.entries[0] = entry
+		heap.Fix(&c.heap, 0)
+	}
+	return true
+}
+
+// Seen returns how many rules have been offered to the collector
+func (c *TopKCollector) Seen() int {
+	return c.seen
+}
+
+// Result returns the kept rules, best first, in the same order TopKRules would give
+func (c *TopKCollector) Result() []models.AssociationRule {
+	ranked := make([]rankedRule, len(c.heap.entries))
+	copy(ranked, c.heap.entries)
+	sort.Slice(ranked, func(i, j int) bool { return c.heap.weaker(ranked[j], ranked[i]) })
+
+	rules := make([]models.AssociationRule, len(ranked))
+	for i, entry := range ranked {
+		rules[i] = entry.rule
+	}
+	return rules
+}
@@ -0,0 +1,73 @@
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// TuneStep records one mining run of a support search
+type TuneStep struct {
+	MinSupport float64
+	Itemsets   int
+}
+
+// TuneResult is the outcome of AutoTuneSupport: the chosen support, the itemsets
+// mined with it and every step of the search
+type TuneResult struct {
+	MinSupport float64
+	Itemsets   []models.FrequentItemset
+	Steps      []TuneStep
+}
+
+// AutoTuneSupport searches for the minimum support whose frequent itemset count is
+// within tolerance (a fraction, e.g. 0.1 for 10%) of targetCount, mining with opts and
+// its MinSupport replaced at every step. The itemset count only falls as the support
+// rises, so the search bisects the transaction count a support threshold stands for and
+// needs about log2 of the transaction count runs at most. When no support lands within
+// the tolerance, the one whose count came closest to the target is returned.
+func AutoTuneSupport(dataset *models.Dataset, opts MineOptions, targetCount int, tolerance float64) (TuneResult, error) {
+	if targetCount < 1 {
+		return TuneResult{}, fmt.Errorf("%w: target itemset count %d must be at least 1", ErrInvalidOption, targetCount)
+	}
+	if tolerance < 0 {
+		return TuneResult{}, fmt.Errorf("%w: tolerance %v must not be negative", ErrInvalidOption, tolerance)
+	}
+	transactionCount := len(dataset.Transactions)
+	if transactionCount == 0 {
+		return TuneResult{}, ErrEmptyDataset
+	}
+
+	var result TuneResult
+	bestDistance := math.Inf(1)
+	low, high := 1, transactionCount
+	for low <= high {
+		count := low + (high-low)/2
+		opts.MinSupport = float64(count) / float64(transactionCount)
+		itemsets, err := MineItemsets(dataset, opts)
+		if err != nil && !errors.Is(err, ErrNoFrequentItemsets) {
+			return TuneResult{}, err
+		}
+		result.Steps = append(result.Steps, TuneStep{MinSupport: opts.MinSupport, Itemsets: len(itemsets)})
+
+		distance := math.Abs(float64(len(itemsets) - targetCount))
+		if distance < bestDistance {
+			bestDistance = distance
+			result.MinSupport, result.Itemsets = opts.MinSupport, itemsets
+		}
+		if distance <= tolerance*float64(targetCount) {
+			break
+		}
+
+		// Too many itemsets means the support is too low
+		if len(itemsets) > targetCount {
+			low = count + 1
+		} else {
+			high = count - 1
+		}
+	}
+
+	return result, nil
+}
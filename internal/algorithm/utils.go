@@ -1,28 +1,24 @@
 package algorithm
 
 import (
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
 	"github.com/RiceaRaul/AprioriGO/internal/models"
 )
 
-// generateAllSubsets generates all non-empty subsets of a set
-func generateAllSubsets(set []string) [][]string {
-	n := len(set)
-	count := 1 << uint(n) // 2^n
-	result := make([][]string, 0, count-1)
-
-	// For each possible subset (excluding empty set)
-	for i := 1; i < count; i++ {
-		subset := make([]string, 0)
-		for j := 0; j < n; j++ {
-			if (i & (1 << uint(j))) > 0 {
-				subset = append(subset, set[j])
-			}
-		}
-		result = append(result, subset)
-	}
+// parallelCountMinTransactions is the dataset size below which item counting stays
+// on one goroutine, since splitting small inputs costs more than it saves
+const parallelCountMinTransactions = 20000
 
-	return result
-}
+// keyDelimiter separates items in itemset keys, as in models.ItemsetKey. It cannot occur
+// in item names read from text sources, unlike "," which is legal inside a quoted CSV field.
+const keyDelimiter = "\x00"
+
+// ruleKeySeparator separates the antecedent and consequent parts of a rule key
+const ruleKeySeparator = "\x00\x00"
 
 // containsItem checks if a transaction contains an item
 func containsItem(transaction models.Transaction, item string) bool {
@@ -44,19 +40,6 @@ func isSubset(items []string, transaction models.Transaction) bool {
 	return true
 }
 
-// slicesEqual checks if two string slices are equal
-func slicesEqual(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
-	}
-	return true
-}
-
 // difference returns the elements in a that are not in b
 func difference(a, b []string) []string {
 	result := make([]string, 0)
@@ -76,3 +59,129 @@ func difference(a, b []string) []string {
 
 	return result
 }
+
+// hasRepeatedItem reports whether an item occurs more than once in items
+func hasRepeatedItem(items []string) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if seen[item] {
+			return true
+		}
+		seen[item] = true
+	}
+	return false
+}
+
+// itemCounts counts how many transactions contain each item. It reads the inverted
+// index when that has already been built and otherwise scans chunks of the
+// transactions on all cores.
+func itemCounts(dataset *models.Dataset) map[string]int {
+	if dataset.HasInvertedIndex() {
+		index := dataset.InvertedIndex()
+		counts := make(map[string]int, len(index))
+		for item, tids := range index {
+			counts[item] = len(tids)
+		}
+		return counts
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	transactions := dataset.Transactions
+	if workers == 1 || len(transactions) < parallelCountMinTransactions {
+		return countItems(transactions, len(dataset.UniqueItems))
+	}
+
+	chunkSize := (len(transactions) + workers - 1) / workers
+	partial := make([]map[string]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w * chunkSize
+		if start >= len(transactions) {
+			break
+		}
+		end := min(start+chunkSize, len(transactions))
+
+		wg.Add(1)
+		go func(w int, chunk []models.Transaction) {
+			defer wg.Done()
+			partial[w] = countItems(chunk, len(dataset.UniqueItems))
+		}(w, transactions[start:end])
+	}
+	wg.Wait()
+
+	counts := make(map[string]int, len(dataset.UniqueItems))
+	for _, chunkCounts := range partial {
+		for item, count := range chunkCounts {
+			counts[item] += count
+		}
+	}
+	return counts
+}
+
+// countItems counts item occurrences in a slice of transactions
+func countItems(transactions []models.Transaction, sizeHint int) map[string]int {
+	counts := make(map[string]int, sizeHint)
+	for _, transaction := range transactions {
+		for _, item := range transaction {
+			counts[item]++
+		}
+	}
+	return counts
+}
+
+// intersectSorted returns the common elements of two ascending int slices
+func intersectSorted(a, b []int) []int {
+	result := make([]int, 0, min(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// sortItemsetsCanonical puts itemsets in the canonical order of mining results: by
+// length, then lexicographically by items, which for sorted items is the order of their
+// models.ItemsetKey. The order depends only on the itemsets, not on map iteration or on
+// how parallel work was scheduled and merged.
+func sortItemsetsCanonical(itemsets []models.FrequentItemset) {
+	sort.SliceStable(itemsets, func(i, j int) bool {
+		if itemsets[i].Length != itemsets[j].Length {
+			return itemsets[i].Length < itemsets[j].Length
+		}
+		a, b := itemsets[i].Items, itemsets[j].Items
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+}
+
+// transactionTotal recovers the dataset size from an itemset's count and support
+func transactionTotal(itemsets []models.FrequentItemset) int {
+	for _, itemset := range itemsets {
+		if itemset.Support > 0 && itemset.Count > 0 {
+			return int(math.Round(float64(itemset.Count) / itemset.Support))
+		}
+	}
+	return 0
+}
+
+// basketValue sums the prices of the given items, treating unknown items as free
+func basketValue(items []string, prices map[string]float64) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += prices[item]
+	}
+	return total
+}
@@ -0,0 +1,63 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// TransactionWeights derives a non-negative weight for every transaction of a dataset,
+// in the order of its Transactions
+type TransactionWeights func(dataset *models.Dataset) ([]float64, error)
+
+// RecencyWeight weights each transaction by exponential decay of its age at now, so a
+// transaction halfLife old counts half as much as one at now. Transactions dated after
+// now count fully. The dataset must have Timestamps, as session logs do.
+func RecencyWeight(halfLife time.Duration, now time.Time) TransactionWeights {
+	return func(dataset *models.Dataset) ([]float64, error) {
+		if halfLife <= 0 {
+			return nil, fmt.Errorf("%w: half-life %v must be positive", ErrInvalidOption, halfLife)
+		}
+		if len(dataset.Timestamps) != len(dataset.Transactions) {
+			return nil, fmt.Errorf("%w: recency weighting needs a timestamp for every transaction", ErrInvalidOption)
+		}
+
+		weights := make([]float64, len(dataset.Timestamps))
+		for i, at := range dataset.Timestamps {
+			age := max(now.Sub(at), 0)
+			weights[i] = math.Exp2(-float64(age) / float64(halfLife))
+		}
+		return weights, nil
+	}
+}
+
+// validateWeights checks that there is one non-negative weight per transaction and
+// that they do not all vanish
+func validateWeights(weights []float64, transactionCount int) error {
+	if len(weights) != transactionCount {
+		return fmt.Errorf("%w: got %d transaction weights for %d transactions", ErrInvalidOption, len(weights), transactionCount)
+	}
+
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) {
+			return fmt.Errorf("%w: transaction weight %v must be a non-negative number", ErrInvalidOption, weight)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("%w: transaction weights sum to zero", ErrInvalidOption)
+	}
+	return nil
+}
+
+// weightedSupport sums the weights of the given transactions as a fraction of the total
+func weightedSupport(tids []int, weights []float64, totalWeight float64) float64 {
+	sum := 0.0
+	for _, tid := range tids {
+		sum += weights[tid]
+	}
+	return sum / totalWeight
+}
@@ -0,0 +1,51 @@
+package loader
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// LoadRuleBlocklist loads rule patterns to suppress from a text file with one pattern
+// per line, written like the output's item lists in any item style: "{bag} => {receipt}",
+// "bag => receipt" or `["bag"] => ["receipt"]`. Blank lines
+// and lines starting with # are skipped. The returned rules carry only their
+// antecedent and consequent.
+func LoadRuleBlocklist(filePath string) ([]models.AssociationRule, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	// Files saved by Excel or Notepad may start with a byte order mark
+	rules := make([]models.AssociationRule, 0)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		antecedent, consequent, ok := strings.Cut(text, "=>")
+		if !ok {
+			return nil, fmt.Errorf("error parsing line %d: %w: missing => in %q", line, ErrInvalidFormat, text)
+		}
+		var rule models.AssociationRule
+		if rule.Antecedent, err = parseItems(strings.TrimSpace(antecedent)); err != nil {
+			return nil, fmt.Errorf("error parsing line %d: %w", line, err)
+		}
+		if rule.Consequent, err = parseItems(strings.TrimSpace(consequent)); err != nil {
+			return nil, fmt.Errorf("error parsing line %d: %w", line, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading blocklist: %w", err)
+	}
+
+	return rules, nil
+}
@@ -3,28 +3,111 @@ package loader
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/RiceaRaul/AprioriGO/internal/models"
 )
 
+// LoadOptions configures how transactions are read
+type LoadOptions struct {
+	// NormalizeItems lowercases item names, converts them to Unicode normalization
+	// form C and collapses runs of whitespace so that variants like "Milk", "MILK",
+	// " milk " and "Café" typed with a combining accent are counted as one item.
+	NormalizeItems bool
+
+	// MaxBasketSize caps the number of distinct items in a basket; larger baskets,
+	// such as data-entry errors or wholesale orders, are dropped. Zero means no cap.
+	MaxBasketSize int
+
+	// TruncateBaskets keeps the first MaxBasketSize items of an oversized basket, in
+	// file order, instead of dropping the basket
+	TruncateBaskets bool
+
+	// ItemTransform, when set, rewrites each item name after normalization and before
+	// baskets are deduplicated, e.g. to map SKUs or product variants to one canonical
+	// product. Returning "" drops the item.
+	ItemTransform func(item string) string
+
+	// KeepMultiplicity records how many times each item was listed in its basket in the
+	// dataset's Quantities, counting names merged by normalization or ItemTransform
+	// together. Transactions and mining support are unchanged: an item is in a basket
+	// or not, however often it was listed.
+	KeepMultiplicity bool
+
+	// KeepOrder records each basket's items in the order they were read, repeats
+	// included, in the dataset's Sequences; for sessions that is the order of the
+	// events. Transactions are still sorted and deduplicated.
+	KeepOrder bool
+
+	// NullTokens are item values that mean no item, such as "NULL" or "NA" written by
+	// database exports. They are matched exactly after trimming whitespace, before
+	// normalization, and skipped like empty cells.
+	NullTokens []string
+
+	// Encoding is the character encoding of the input, transcoded to UTF-8 while
+	// reading. Empty means UTF-8. A leading UTF-8 byte order mark is always dropped.
+	Encoding Encoding
+
+	// Observer, when set, is notified at the start and end of the load phase of
+	// LoadFromReader, LoadFromCSVWithOptions, LoadFromOneHotReader,
+	// LoadFromOneHotCSVWithOptions, LoadFromWideCSV and LoadSessionsFromCSV
+	Observer models.Observer
+}
+
+// LoadReport describes the adjustments made while loading a dataset
+type LoadReport struct {
+	MergedItems      int // Distinct raw item names folded into another name by normalization or ItemTransform
+	DroppedItems     int // Distinct raw item names dropped because they normalized or transformed to ""
+	OversizedBaskets int // Baskets dropped or truncated for exceeding MaxBasketSize
+
+	// SkippedNulls counts the item cells skipped for each null token of the options;
+	// tokens that never occurred are left out
+	SkippedNulls map[string]int
+}
+
 // LoadFromCSV loads transactions from a CSV file with basket and item columns
 func LoadFromCSV(filePath string) (*models.Dataset, error) {
+	dataset, _, err := LoadFromCSVWithOptions(filePath, LoadOptions{})
+	return dataset, err
+}
+
+// LoadFromCSVWithOptions loads transactions from a CSV file using the given options
+func LoadFromCSVWithOptions(filePath string, opts LoadOptions) (*models.Dataset, *LoadReport, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("error opening file: %v", err)
+		return nil, nil, fmt.Errorf("error opening file: %w", err)
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	return LoadFromReader(file, opts)
+}
+
+// LoadFromReader loads transactions from CSV data with basket and item columns. Gzip
+// compressed data is recognized by its magic bytes and decompressed transparently, and
+// the text is decoded as described by the options' Encoding.
+func LoadFromReader(r io.Reader, opts LoadOptions) (*models.Dataset, *LoadReport, error) {
+	defer models.StartPhase(opts.Observer, models.PhaseLoad)()
+
+	r, err := textReader(r, opts.Encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := csv.NewReader(r)
 	reader.FieldsPerRecord = -1 // Allow variable number of fields
 	records, err := reader.ReadAll()
 	if err != nil {
-		return nil, fmt.Errorf("error reading CSV: %v", err)
+		return nil, nil, fmt.Errorf("error reading CSV: %w", err)
 	}
 
+	report := &LoadReport{}
+	transform := newItemTransformer(opts)
+
 	// Group by basket
 	basketMap := make(map[string][]string)
 	headerRow := true // Assume first row is header
@@ -33,8 +116,7 @@ func LoadFromCSV(filePath string) (*models.Dataset, error) {
 		// Skip header row
 		if i == 0 && headerRow {
 			// Check if first row looks like a header
-			if len(record) >= 2 && (strings.Contains(strings.ToLower(record[0]), "basket") ||
-				strings.Contains(strings.ToLower(record[1]), "item")) {
+			if looksLikeHeader(record) {
 				continue
 			} else {
 				headerRow = false // Not a header, process this row
@@ -47,7 +129,7 @@ func LoadFromCSV(filePath string) (*models.Dataset, error) {
 		}
 
 		basket := strings.TrimSpace(record[0])
-		item := strings.TrimSpace(record[1])
+		item := transform.apply(strings.TrimSpace(record[1]))
 
 		if basket == "" || item == "" {
 			continue
@@ -56,27 +138,104 @@ func LoadFromCSV(filePath string) (*models.Dataset, error) {
 		basketMap[basket] = append(basketMap[basket], item)
 	}
 
+	report.MergedItems = transform.merged()
+	report.DroppedItems = transform.droppedNames()
+	report.SkippedNulls = transform.skippedNulls()
+
+	dataset := buildDataset(basketMap, opts, report)
+	if len(dataset.Transactions) == 0 {
+		return nil, nil, fmt.Errorf("error reading CSV: %w", ErrEmptyDataset)
+	}
+
+	return dataset, report, nil
+}
+
+// looksLikeHeader reports whether a first row names the basket and item columns
+// rather than holding data
+func looksLikeHeader(record []string) bool {
+	return len(record) >= 2 && (strings.Contains(strings.ToLower(record[0]), "basket") ||
+		strings.Contains(strings.ToLower(record[1]), "item"))
+}
+
+// buildDataset converts grouped basket items into a dataset, applying the basket size
+// cap of the options and counting the baskets it affects in report when that is not nil
+func buildDataset(basketMap map[string][]string, opts LoadOptions, report *LoadReport) *models.Dataset {
+	return buildTimedDataset(basketMap, nil, opts, report)
+}
+
+// orderedItems returns the items in the order they were read, leaving out any that
+// truncation dropped, which no longer have a quantity
+func orderedItems(items []string, quantities map[string]int) []string {
+	sequence := make([]string, 0, len(items))
+	for _, item := range items {
+		if quantities[item] > 0 {
+			sequence = append(sequence, item)
+		}
+	}
+	return sequence
+}
+
+// buildTimedDataset builds a dataset like buildDataset and, when basketTimes is not
+// nil, fills in the timestamp of each transaction from the time of its basket
+func buildTimedDataset(basketMap map[string][]string, basketTimes map[string]time.Time,
+	opts LoadOptions, report *LoadReport) *models.Dataset {
 	// Convert to transactions
 	dataset := &models.Dataset{
 		Transactions: make([]models.Transaction, 0, len(basketMap)),
 		ItemsMap:     make(map[string]bool),
 	}
 
-	for _, items := range basketMap {
-		// Remove duplicates within a basket
-		uniqueItems := make(map[string]bool)
+	// Order baskets by ID so transaction order, and any seeded sample of it, is
+	// the same on every load
+	basketIDs := make([]string, 0, len(basketMap))
+	for basketID := range basketMap {
+		basketIDs = append(basketIDs, basketID)
+	}
+	sort.Strings(basketIDs)
+
+	for _, basketID := range basketIDs {
+		items := basketMap[basketID]
+
+		// Remove duplicates within a basket, keeping the first occurrence of each item
+		quantities := make(map[string]int)
+		transaction := make(models.Transaction, 0, len(items))
 		for _, item := range items {
-			uniqueItems[item] = true
-			dataset.ItemsMap[item] = true
+			if quantities[item] == 0 {
+				transaction = append(transaction, item)
+			}
+			quantities[item]++
 		}
 
-		// Create transaction with unique items
-		transaction := make(models.Transaction, 0, len(uniqueItems))
-		for item := range uniqueItems {
-			transaction = append(transaction, item)
+		if opts.MaxBasketSize > 0 && len(transaction) > opts.MaxBasketSize {
+			if report != nil {
+				report.OversizedBaskets++
+			}
+			if !opts.TruncateBaskets {
+				continue
+			}
+			transaction = transaction[:opts.MaxBasketSize]
+			for _, item := range items {
+				if !slices.Contains(transaction, item) {
+					delete(quantities, item)
+				}
+			}
+		}
+
+		for _, item := range transaction {
+			dataset.ItemsMap[item] = true
 		}
+		sort.Strings(transaction)
 
 		dataset.Transactions = append(dataset.Transactions, transaction)
+		if basketTimes != nil {
+			dataset.Timestamps = append(dataset.Timestamps, basketTimes[basketID])
+		}
+		if opts.KeepMultiplicity {
+			dataset.Quantities = append(dataset.Quantities, quantities)
+		}
+		if opts.KeepOrder {
+			dataset.Sequences = append(dataset.Sequences, orderedItems(items, quantities))
+		}
 	}
 
 	// Create slice of unique items
@@ -87,5 +246,5 @@ func LoadFromCSV(filePath string) (*models.Dataset, error) {
 
 	sort.Strings(dataset.UniqueItems)
 
-	return dataset, nil
+	return dataset
 }
@@ -0,0 +1,121 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CSVSource is a models.TransactionSource that reads the baskets of a basket,item CSV
+// file one at a time, re-reading the file on every Reset instead of loading it. Each
+// basket is assembled from consecutive rows with the same basket ID, so the rows of a
+// basket must be adjacent, e.g. sorted by basket ID; a basket split across the file is
+// read as several baskets. LoadOptions apply as in LoadFromCSVWithOptions.
+type CSVSource struct {
+	path      string
+	opts      LoadOptions
+	transform *itemTransformer
+
+	file    *os.File
+	reader  *csv.Reader
+	pending []string // First row of the next basket, already read
+	err     error
+}
+
+// NewCSVSource creates a source for a basket,item CSV file. The file is opened by the
+// first Reset.
+func NewCSVSource(path string, opts LoadOptions) *CSVSource {
+	return &CSVSource{path: path, opts: opts, transform: newItemTransformer(opts)}
+}
+
+// Reset reopens the file and skips its header row, if any
+func (s *CSVSource) Reset() error {
+	s.Close()
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		s.err = fmt.Errorf("error opening file: %w", err)
+		return s.err
+	}
+	s.file = file
+	r, err := textReader(file, s.opts.Encoding)
+	if err != nil {
+		s.err = err
+		return s.err
+	}
+	s.reader = csv.NewReader(r)
+	s.reader.FieldsPerRecord = -1 // Allow variable number of fields
+	s.err = nil
+
+	s.pending = s.read()
+	if s.pending != nil && looksLikeHeader(s.pending) {
+		s.pending = s.read()
+	}
+	return s.err
+}
+
+// Next returns the items of the next basket, deduplicated and capped by the options.
+// Rows with an empty basket or item, or fewer than two columns, are skipped.
+func (s *CSVSource) Next() ([]string, bool) {
+	for s.pending != nil {
+		basket := strings.TrimSpace(s.pending[0])
+		seen := make(map[string]bool)
+		transaction := make([]string, 0)
+		for s.pending != nil && strings.TrimSpace(s.pending[0]) == basket {
+			item := s.transform.apply(strings.TrimSpace(s.pending[1]))
+			if item != "" && !seen[item] {
+				seen[item] = true
+				transaction = append(transaction, item)
+			}
+			s.pending = s.read()
+		}
+
+		if basket == "" || len(transaction) == 0 {
+			continue
+		}
+		if s.opts.MaxBasketSize > 0 && len(transaction) > s.opts.MaxBasketSize {
+			if !s.opts.TruncateBaskets {
+				continue
+			}
+			transaction = transaction[:s.opts.MaxBasketSize]
+		}
+		return transaction, true
+	}
+	return nil, false
+}
+
+// Err returns the error that ended the current pass, if any
+func (s *CSVSource) Err() error {
+	return s.err
+}
+
+// Close closes the file. The source can still be reset and read again afterwards.
+func (s *CSVSource) Close() error {
+	s.pending = nil
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// read returns the next row with at least two columns, or nil at the end of the file
+// or on an error, which is kept for Err
+func (s *CSVSource) read() []string {
+	for {
+		record, err := s.reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			s.err = fmt.Errorf("error reading CSV: %w", err)
+			return nil
+		}
+		if len(record) >= 2 {
+			return record
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// gzipMagic is the two-byte header that starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decompressed returns a reader over the uncompressed contents of r, detecting gzip
+// data from its magic bytes rather than a file extension so piped input works too.
+// Other input is returned as is.
+func decompressed(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	header, err := buffered.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+	if !bytes.Equal(header, gzipMagic) {
+		return buffered, nil
+	}
+
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gzip input: %w", err)
+	}
+	return gz, nil
+}
@@ -0,0 +1,102 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of an input file
+type Encoding string
+
+const (
+	// EncodingUTF8 is UTF-8, with or without a byte order mark. The empty Encoding
+	// means UTF-8 too.
+	EncodingUTF8 Encoding = "utf-8"
+	// EncodingLatin1 is ISO-8859-1, as written by many older Windows and database
+	// exports, where every byte is one character
+	EncodingLatin1 Encoding = "latin-1"
+)
+
+// utf8BOM is the byte order mark Windows tools often put at the start of UTF-8 files
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+// ParseEncoding converts an encoding name such as utf-8, latin1 or iso-8859-1 to an
+// Encoding, ignoring case
+func ParseEncoding(name string) (Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "utf-8", "utf8":
+		return EncodingUTF8, nil
+	case "latin-1", "latin1", "iso-8859-1":
+		return EncodingLatin1, nil
+	default:
+		return "", fmt.Errorf("unknown encoding %q, must be utf-8 or latin-1", name)
+	}
+}
+
+// textReader returns a reader of UTF-8 text over r: gzip data is decompressed, a
+// leading UTF-8 byte order mark is dropped so it does not become part of the first
+// value, and latin-1 input is transcoded
+func textReader(r io.Reader, encoding Encoding) (io.Reader, error) {
+	r, err := decompressed(r)
+	if err != nil {
+		return nil, err
+	}
+	buffered := bufio.NewReader(r)
+
+	switch encoding {
+	case "", EncodingUTF8:
+		header, err := buffered.Peek(len(utf8BOM))
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("error reading input: %w", err)
+		}
+		if bytes.Equal(header, utf8BOM) {
+			_, _ = buffered.Discard(len(utf8BOM)) // The bytes were just peeked
+		}
+		return buffered, nil
+	case EncodingLatin1:
+		return &latin1Reader{r: buffered}, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+}
+
+// latin1Reader transcodes ISO-8859-1 to UTF-8. Each byte is the code point of the same
+// value, so bytes from 0x80 up become two-byte UTF-8 sequences.
+type latin1Reader struct {
+	r       *bufio.Reader
+	buf     []byte
+	pending []byte // Rest of a sequence that did not fit the last read
+}
+
+// Read fills p with transcoded bytes
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(l.pending) > 0 {
+			copied := copy(p[n:], l.pending)
+			l.pending = l.pending[copied:]
+			n += copied
+			continue
+		}
+
+		b, err := l.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if b < utf8.RuneSelf {
+			p[n] = b
+			n++
+			continue
+		}
+		l.buf = utf8.AppendRune(l.buf[:0], rune(b))
+		l.pending = l.buf
+	}
+	return n, nil
+}
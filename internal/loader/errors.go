@@ -0,0 +1,18 @@
+package loader
+
+import (
+	"errors"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Sentinel errors for errors.Is. Returned errors wrap these with details; a missing
+// file can be detected with errors.Is(err, fs.ErrNotExist).
+var (
+	// ErrEmptyDataset is returned when a source yields no transactions. It is the
+	// same value as models.ErrEmptyDataset.
+	ErrEmptyDataset = models.ErrEmptyDataset
+
+	// ErrInvalidFormat is returned when the contents of a file cannot be parsed
+	ErrInvalidFormat = errors.New("invalid format")
+)
@@ -0,0 +1,98 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// parseItems parses an item list in any of the output package's item styles, the
+// inverse of its item formatting: "{a,b}" (brace), "["a","b"]" (json) or "a|b" (pipe).
+// The style is told by the first character, so a pipe list whose first item starts
+// with a brace or bracket is read as one of the other styles.
+func parseItems(s string) ([]string, error) {
+	switch {
+	case strings.HasPrefix(s, "{"):
+		return parseBraceItems(s)
+	case strings.HasPrefix(s, "["):
+		items := make([]string, 0)
+		if err := json.Unmarshal([]byte(s), &items); err != nil {
+			return nil, fmt.Errorf("%w: item list %q is not a JSON array of strings", ErrInvalidFormat, s)
+		}
+		return items, nil
+	case s == "":
+		return []string{}, nil
+	default:
+		return strings.Split(s, "|"), nil
+	}
+}
+
+// parseBraceItems parses an item list written as "{a,b,c}". Quoted items may contain
+// delimiters, braces and escaped quotes or backslashes. A bare item containing any of
+// those characters cannot be split unambiguously, so it is reported as an error
+// instead of being guessed at.
+func parseBraceItems(s string) ([]string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("%w: item list %q is not enclosed in braces", ErrInvalidFormat, s)
+	}
+
+	inner := s[1 : len(s)-1]
+	items := make([]string, 0)
+	if inner == "" {
+		return items, nil
+	}
+
+	for i := 0; ; {
+		var item string
+		if inner[i] == '"' {
+			var b strings.Builder
+			closed := false
+			for i++; i < len(inner); i++ {
+				c := inner[i]
+				if c == '\\' {
+					if i+1 >= len(inner) || (inner[i+1] != '"' && inner[i+1] != '\\') {
+						return nil, fmt.Errorf("%w: invalid escape in item list %q", ErrInvalidFormat, s)
+					}
+					i++
+					b.WriteByte(inner[i])
+					continue
+				}
+				if c == '"' {
+					closed = true
+					i++
+					break
+				}
+				b.WriteByte(c)
+			}
+			if !closed {
+				return nil, fmt.Errorf("%w: unterminated quoted item in item list %q", ErrInvalidFormat, s)
+			}
+			if i < len(inner) && inner[i] != ',' {
+				return nil, fmt.Errorf("%w: unexpected text after quoted item in item list %q", ErrInvalidFormat, s)
+			}
+			item = b.String()
+		} else {
+			end := strings.IndexByte(inner[i:], ',')
+			if end < 0 {
+				end = len(inner) - i
+			}
+			item = inner[i : i+end]
+			if item == "" || strings.ContainsAny(item, "{}\"\\") || strings.TrimSpace(item) != item {
+				return nil, fmt.Errorf("%w: ambiguous item %q in item list %q: items with braces, quotes, "+
+					"backslashes or surrounding whitespace must be quoted", ErrInvalidFormat, item, s)
+			}
+			i += end
+		}
+
+		items = append(items, item)
+		if i >= len(inner) {
+			return items, nil
+		}
+
+		// Skip the delimiter; a trailing one leaves an empty item, which is rejected above
+		i++
+		if i >= len(inner) {
+			return nil, fmt.Errorf("%w: trailing delimiter in item list %q", ErrInvalidFormat, s)
+		}
+	}
+}
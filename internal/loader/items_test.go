@@ -0,0 +1,68 @@
+package loader
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+	"github.com/RiceaRaul/AprioriGO/internal/output"
+)
+
+func TestParseItemsStyles(t *testing.T) {
+	tests := []struct {
+		cell string
+		want []string
+	}{
+		{`{milk,bread}`, []string{"milk", "bread"}},
+		{`{"Smith, John",milk}`, []string{"Smith, John", "milk"}},
+		{`{}`, []string{}},
+		{`milk|bread`, []string{"milk", "bread"}},
+		{`milk`, []string{"milk"}},
+		{``, []string{}},
+		{`["milk","a \"b\""]`, []string{"milk", `a "b"`}},
+		{`[]`, []string{}},
+	}
+	for _, test := range tests {
+		got, err := parseItems(test.cell)
+		if err != nil {
+			t.Errorf("parseItems(%q): %v", test.cell, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseItems(%q) = %q, want %q", test.cell, got, test.want)
+		}
+	}
+
+	for _, cell := range []string{`{milk`, `{a,{b}}`, `["milk"`, `[1,2]`} {
+		if _, err := parseItems(cell); err == nil {
+			t.Errorf("parseItems(%q) accepted an invalid list", cell)
+		}
+	}
+}
+
+func TestRulesRoundTripItemStyles(t *testing.T) {
+	rules := []models.AssociationRule{
+		{Antecedent: []string{"milk", "eggs"}, Consequent: []string{"bread"}, Support: 0.5, Confidence: 1, Lift: 1},
+		{Antecedent: []string{"jam, strawberry"}, Consequent: []string{"toast"}, Support: 0.25, Confidence: 0.5, Lift: 2},
+	}
+
+	for _, style := range []output.ItemStyle{output.ItemStyleBrace, output.ItemStylePipe, output.ItemStyleJSON} {
+		t.Run(string(style), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.csv")
+			if err := output.SaveRulesToCSVWithOptions(rules, path, output.CSVOptions{ItemStyle: style}); err != nil {
+				t.Fatalf("SaveRulesToCSVWithOptions: %v", err)
+			}
+			loaded, err := LoadRulesFromCSV(path)
+			if err != nil {
+				t.Fatalf("LoadRulesFromCSV: %v", err)
+			}
+			for i, rule := range loaded {
+				if !reflect.DeepEqual(rule.Antecedent, rules[i].Antecedent) || !reflect.DeepEqual(rule.Consequent, rules[i].Consequent) {
+					t.Errorf("rule %d: got %q => %q, want %q => %q",
+						i, rule.Antecedent, rule.Consequent, rules[i].Antecedent, rules[i].Consequent)
+				}
+			}
+		})
+	}
+}
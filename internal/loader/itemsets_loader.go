@@ -0,0 +1,105 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// LoadItemsetsFromCSV loads frequent itemsets from a CSV file written by the output
+// package. Columns are matched by header name and only itemsets is required; the
+// length is taken from the items. Items are sorted and itemsets listed twice
+// in different orders are kept once, so the result can be passed straight to rule
+// generation whatever produced the file. When every row has a support_count, supports
+// are recomputed from the counts, undoing the rounding of the support column.
+func LoadItemsetsFromCSV(filePath string) ([]models.FrequentItemset, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("error reading CSV: %w: missing header", ErrInvalidFormat)
+	}
+
+	// Files written for Excel start with a byte order mark
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	if _, ok := columns["itemsets"]; !ok {
+		return nil, fmt.Errorf("error reading CSV: %w: missing itemsets column", ErrInvalidFormat)
+	}
+
+	itemsets := make([]models.FrequentItemset, 0, len(records)-1)
+	for i, record := range records[1:] {
+		itemset, err := parseItemsetRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing row %d: %w", i+2, err)
+		}
+		itemsets = append(itemsets, itemset)
+	}
+
+	restoreSupports(itemsets)
+	return models.CanonicalItemsets(itemsets), nil
+}
+
+// restoreSupports replaces rounded supports with count / transactions when every
+// itemset has a count. The transaction count is estimated from the itemset with the
+// largest count, whose rounded support gives the most precise estimate.
+func restoreSupports(itemsets []models.FrequentItemset) {
+	var best models.FrequentItemset
+	for _, itemset := range itemsets {
+		if itemset.Count <= 0 {
+			return
+		}
+		if itemset.Count > best.Count {
+			best = itemset
+		}
+	}
+	if best.Support <= 0 {
+		return
+	}
+
+	transactions := math.Round(float64(best.Count) / best.Support)
+	for i := range itemsets {
+		itemsets[i].Support = float64(itemsets[i].Count) / transactions
+	}
+}
+
+// parseItemsetRecord parses one CSV record into an itemset using the header column positions
+func parseItemsetRecord(record []string, columns map[string]int) (models.FrequentItemset, error) {
+	var itemset models.FrequentItemset
+	var err error
+
+	if itemset.Items, err = parseItems(record[columns["itemsets"]]); err != nil {
+		return itemset, err
+	}
+	itemset.Length = len(itemset.Items)
+
+	if index, ok := columns["support"]; ok {
+		value := strings.TrimSpace(record[index])
+		if itemset.Support, err = strconv.ParseFloat(value, 64); err != nil {
+			return itemset, fmt.Errorf("%w: invalid support value %q", ErrInvalidFormat, value)
+		}
+	}
+	if index, ok := columns["support_count"]; ok {
+		value := strings.TrimSpace(record[index])
+		if itemset.Count, err = strconv.Atoi(value); err != nil {
+			return itemset, fmt.Errorf("%w: invalid support_count value %q", ErrInvalidFormat, value)
+		}
+	}
+
+	return itemset, nil
+}
@@ -0,0 +1,133 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+	"github.com/RiceaRaul/AprioriGO/internal/output"
+)
+
+// basketDataset is a small dataset with frequent itemsets up to length 4
+func basketDataset() *models.Dataset {
+	return models.NewDataset([]models.Transaction{
+		{"bread", "butter", "milk"},
+		{"bread", "butter", "jam", "milk"},
+		{"bread", "milk"},
+		{"butter", "jam"},
+		{"bread", "butter", "jam", "milk"},
+		{"eggs", "milk"},
+		{"bread", "eggs", "milk"},
+		{"bread", "butter"},
+		{"butter", "jam", "milk"},
+		{"bread", "butter", "eggs", "jam", "milk"},
+		{"jam"},
+		{"bread", "butter", "milk"},
+	})
+}
+
+// sameRules checks that two rule sets hold the same rules with the same metrics
+func sameRules(t *testing.T, got, want []models.AssociationRule) {
+	t.Helper()
+	algorithm.SortRules(got, algorithm.MetricConfidence)
+	algorithm.SortRules(want, algorithm.MetricConfidence)
+	if len(want) == 0 {
+		t.Fatal("no rules to compare")
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rules, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("rule %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestItemsetsRoundTripRegeneratesRules(t *testing.T) {
+	dataset := basketDataset()
+
+	for _, maxLength := range []int{2, 3, 4} {
+		itemsets, err := algorithm.MineItemsets(dataset, algorithm.MineOptions{MinSupport: 0.15, MaxLength: maxLength})
+		if err != nil {
+			t.Fatalf("MineItemsets: %v", err)
+		}
+		path := filepath.Join(t.TempDir(), "itemsets.csv")
+		if err := output.SaveItemsetsToCSV(itemsets, path); err != nil {
+			t.Fatalf("SaveItemsetsToCSV: %v", err)
+		}
+
+		loaded, err := LoadItemsetsFromCSV(path)
+		if err != nil {
+			t.Fatalf("LoadItemsetsFromCSV: %v", err)
+		}
+		if missing := algorithm.MissingSubsets(loaded); missing != 0 {
+			t.Errorf("max length %d: %d subsets missing from a complete itemsets file", maxLength, missing)
+		}
+
+		// Regenerate at a confidence other than the one the file might have been mined for
+		for _, confidence := range []float64{0.3, 0.7} {
+			opts := algorithm.RuleOptions{MinConfidence: confidence}
+			sameRules(t, algorithm.GenerateRules(loaded, opts), algorithm.GenerateRules(itemsets, opts))
+		}
+	}
+}
+
+func TestMaximalItemsetsRoundTripNeedsDataset(t *testing.T) {
+	dataset := basketDataset()
+	itemsets, err := algorithm.MineItemsets(dataset, algorithm.MineOptions{MinSupport: 0.15, MaxLength: 4})
+	if err != nil {
+		t.Fatalf("MineItemsets: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "maximal.csv")
+	if err := output.SaveItemsetsToCSV(algorithm.FilterMaximal(itemsets), path); err != nil {
+		t.Fatalf("SaveItemsetsToCSV: %v", err)
+	}
+
+	loaded, err := LoadItemsetsFromCSV(path)
+	if err != nil {
+		t.Fatalf("LoadItemsetsFromCSV: %v", err)
+	}
+	if algorithm.MissingSubsets(loaded) == 0 {
+		t.Fatal("no subsets reported missing from a maximal itemsets file")
+	}
+
+	// With the dataset the missing supports are counted, giving the rules of the
+	// maximal itemsets with exact metrics
+	opts := algorithm.RuleOptions{MinConfidence: 0.5, Dataset: dataset}
+	want := algorithm.GenerateRules(itemsets, algorithm.RuleOptions{MinConfidence: 0.5, MaximalOnly: true})
+	sameRules(t, algorithm.GenerateRules(loaded, opts), want)
+}
+
+func TestLoadItemsetsUnsorted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "itemsets.csv")
+	contents := "support,itemsets,length,support_count\n" +
+		"0.500000,{b},1,2\n" +
+		"0.750000,{a},1,3\n" +
+		"0.500000,\"{b,a}\",2,2\n" +
+		"0.500000,\"{a,b}\",2,2\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	itemsets, err := LoadItemsetsFromCSV(path)
+	if err != nil {
+		t.Fatalf("LoadItemsetsFromCSV: %v", err)
+	}
+	if len(itemsets) != 3 {
+		t.Fatalf("got %d itemsets, want 3 with {b,a} and {a,b} kept once", len(itemsets))
+	}
+	for _, itemset := range itemsets {
+		if itemset.Length == 2 && !reflect.DeepEqual(itemset.Items, []string{"a", "b"}) {
+			t.Errorf("got items %q, want them sorted", itemset.Items)
+		}
+	}
+
+	rules := algorithm.GenerateAssociationRules(itemsets, 0.5)
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want {a} => {b} and {b} => {a} once each", len(rules))
+	}
+}
@@ -0,0 +1,65 @@
+package loader
+
+import (
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// MergeDatasets combines several datasets into one by concatenating their transactions.
+// Basket IDs are resolved per input at load time, so each input's transactions are
+// treated as distinct baskets and can never collide with baskets from another input.
+// Timestamps, Quantities and Sequences are each kept only when every input has them.
+func MergeDatasets(datasets ...*models.Dataset) *models.Dataset {
+	total := 0
+	timed, counted, ordered := true, true, true
+	for _, dataset := range datasets {
+		if dataset != nil {
+			total += len(dataset.Transactions)
+			timed = timed && dataset.Timestamps != nil
+			counted = counted && dataset.Quantities != nil
+			ordered = ordered && dataset.Sequences != nil
+		}
+	}
+
+	transactions := make([]models.Transaction, 0, total)
+	var timestamps []time.Time
+	if timed {
+		timestamps = make([]time.Time, 0, total)
+	}
+	var quantities []map[string]int
+	if counted {
+		quantities = make([]map[string]int, 0, total)
+	}
+	var sequences [][]string
+	if ordered {
+		sequences = make([][]string, 0, total)
+	}
+	for _, dataset := range datasets {
+		if dataset == nil {
+			continue
+		}
+
+		for _, transaction := range dataset.Transactions {
+			// Copy so the merged dataset does not share backing arrays with its inputs
+			copied := make(models.Transaction, len(transaction))
+			copy(copied, transaction)
+			transactions = append(transactions, copied)
+		}
+		if timed {
+			timestamps = append(timestamps, dataset.Timestamps...)
+		}
+		if counted {
+			quantities = append(quantities, dataset.Quantities...)
+		}
+		if ordered {
+			sequences = append(sequences, dataset.Sequences...)
+		}
+	}
+
+	merged := models.NewDataset(transactions)
+	merged.Timestamps = timestamps
+	merged.Quantities = quantities
+	merged.Sequences = sequences
+	return merged
+}
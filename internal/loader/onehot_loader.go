@@ -0,0 +1,124 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// LoadFromOneHotCSV loads a one-hot encoded CSV, as exported by pandas or used by
+// mlxtend: the header names the items and each row is a transaction with a 0/1 or
+// true/false cell per item. A column with an empty header, such as a pandas index,
+// is ignored, as is a leading byte order mark. Rows without any true cell become
+// empty transactions so supports are computed over every row.
+func LoadFromOneHotCSV(filePath string) (*models.Dataset, error) {
+	dataset, _, err := LoadFromOneHotCSVWithOptions(filePath, LoadOptions{})
+	return dataset, err
+}
+
+// LoadFromOneHotCSVWithOptions loads a one-hot encoded CSV file using the given options
+func LoadFromOneHotCSVWithOptions(filePath string, opts LoadOptions) (*models.Dataset, *LoadReport, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	return LoadFromOneHotReader(file, opts)
+}
+
+// LoadFromOneHotReader loads one-hot encoded CSV data like LoadFromOneHotCSV. The
+// item names of the header are normalized and transformed as described by the
+// options, so columns that end up with the same name are merged into one item and a
+// column whose name is dropped is ignored. Cells holding a null token count as false.
+// Baskets over MaxBasketSize are dropped or truncated to their first items in column
+// order. KeepMultiplicity and KeepOrder do not apply, as each item occurs once per row.
+func LoadFromOneHotReader(r io.Reader, opts LoadOptions) (*models.Dataset, *LoadReport, error) {
+	defer models.StartPhase(opts.Observer, models.PhaseLoad)()
+
+	r, err := textReader(r, opts.Encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := csv.NewReader(r)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("error reading CSV: %w: missing header", ErrInvalidFormat)
+	}
+
+	transform := newItemTransformer(opts)
+	header := make([]string, len(records[0]))
+	items := make([]string, len(records[0])) // Canonical item of each column, "" if ignored
+	seen := make(map[string]bool)
+	for i, name := range records[0] {
+		header[i] = strings.TrimSpace(name)
+		if header[i] != "" && seen[header[i]] {
+			return nil, nil, fmt.Errorf("error reading CSV: %w: duplicate item column %q", ErrInvalidFormat, header[i])
+		}
+		seen[header[i]] = true
+		items[i] = transform.apply(header[i])
+	}
+
+	report := &LoadReport{MergedItems: transform.merged(), DroppedItems: transform.droppedNames()}
+	transactions := make([]models.Transaction, 0, len(records)-1)
+	for row, record := range records[1:] {
+		transaction := make(models.Transaction, 0)
+		included := make(map[string]bool)
+		for i, cell := range record {
+			if items[i] == "" || transform.skipNull(strings.TrimSpace(cell)) {
+				continue
+			}
+
+			present, err := parseOneHotCell(cell)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing row %d, column %q: %w", row+2, header[i], err)
+			}
+			if present && !included[items[i]] {
+				included[items[i]] = true
+				transaction = append(transaction, items[i])
+			}
+		}
+
+		if opts.MaxBasketSize > 0 && len(transaction) > opts.MaxBasketSize {
+			report.OversizedBaskets++
+			if !opts.TruncateBaskets {
+				continue
+			}
+			transaction = transaction[:opts.MaxBasketSize]
+		}
+		sort.Strings(transaction)
+		transactions = append(transactions, transaction)
+	}
+	report.SkippedNulls = transform.skippedNulls()
+
+	if len(transactions) == 0 {
+		return nil, nil, fmt.Errorf("error reading CSV: %w", ErrEmptyDataset)
+	}
+
+	return models.NewDataset(transactions), report, nil
+}
+
+// parseOneHotCell interprets a one-hot cell; empty cells count as false
+func parseOneHotCell(cell string) (bool, error) {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return false, nil
+	}
+	if value, err := strconv.ParseBool(cell); err == nil {
+		return value, nil
+	}
+	if value, err := strconv.ParseFloat(cell, 64); err == nil {
+		return value != 0, nil
+	}
+	return false, fmt.Errorf("%w: invalid one-hot value %q", ErrInvalidFormat, cell)
+}
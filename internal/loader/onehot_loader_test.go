@@ -0,0 +1,50 @@
+package loader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+func TestLoadFromOneHotReaderOptions(t *testing.T) {
+	data := ",Milk,milk ,Bread,Eggs,Bag\n" +
+		"0,1,0,1,0,1\n" +
+		"1,0,1,NA,1,0\n" +
+		"2,1,1,1,1,1\n" +
+		"3,0,0,0,NA,0\n"
+	opts := LoadOptions{
+		NormalizeItems: true,
+		NullTokens:     []string{"NA"},
+		MaxBasketSize:  2,
+		ItemTransform: func(item string) string {
+			if item == "bag" {
+				return ""
+			}
+			return item
+		},
+	}
+
+	dataset, report, err := LoadFromOneHotReader(strings.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("LoadFromOneHotReader: %v", err)
+	}
+	want := []models.Transaction{{"bread", "milk"}, {"eggs", "milk"}, {}}
+	if !reflect.DeepEqual(dataset.Transactions, want) {
+		t.Errorf("transactions = %v, want %v", dataset.Transactions, want)
+	}
+	wantReport := &LoadReport{MergedItems: 1, DroppedItems: 1, OversizedBaskets: 1, SkippedNulls: map[string]int{"NA": 2}}
+	if !reflect.DeepEqual(report, wantReport) {
+		t.Errorf("report = %+v, want %+v", report, wantReport)
+	}
+
+	opts.TruncateBaskets = true
+	dataset, _, err = LoadFromOneHotReader(strings.NewReader(data), opts)
+	if err != nil {
+		t.Fatalf("LoadFromOneHotReader: %v", err)
+	}
+	if got := dataset.Transactions[2]; !reflect.DeepEqual(got, models.Transaction{"bread", "milk"}) {
+		t.Errorf("truncated basket = %v, want its first 2 items in column order", got)
+	}
+}
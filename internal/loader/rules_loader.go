@@ -0,0 +1,132 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// LoadRulesFromCSV loads association rules from a CSV file written by the output
+// package. Columns are matched by header name, so files with fewer metric columns
+// load too; only antecedents and consequents are required.
+func LoadRulesFromCSV(filePath string) ([]models.AssociationRule, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("error reading CSV: %w: missing header", ErrInvalidFormat)
+	}
+
+	// Files written for Excel start with a byte order mark, and the mlxtend layout
+	// spells column names with spaces, e.g. "antecedent support"
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		columns[strings.ReplaceAll(name, " ", "_")] = i
+	}
+	for _, required := range []string{"antecedents", "consequents"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("error reading CSV: %w: missing %s column", ErrInvalidFormat, required)
+		}
+	}
+
+	rules := make([]models.AssociationRule, 0, len(records)-1)
+	for i, record := range records[1:] {
+		rule, err := parseRuleRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing row %d: %w", i+2, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseRuleRecord parses one CSV record into a rule using the header column positions
+func parseRuleRecord(record []string, columns map[string]int) (models.AssociationRule, error) {
+	var rule models.AssociationRule
+	var err error
+
+	if rule.Antecedent, err = parseItems(record[columns["antecedents"]]); err != nil {
+		return rule, err
+	}
+	if rule.Consequent, err = parseItems(record[columns["consequents"]]); err != nil {
+		return rule, err
+	}
+
+	floats := []struct {
+		column string
+		target *float64
+	}{
+		{"support", &rule.Support},
+		{"confidence", &rule.Confidence},
+		{"lift", &rule.Lift},
+		{"leverage", &rule.LeverageMetric},
+		{"conviction", &rule.ConvictionMetric},
+		{"antecedent_support", &rule.AntecedentSupport},
+		{"consequent_support", &rule.ConsequentSupport},
+		{"score", &rule.Score},
+	}
+	for _, field := range floats {
+		index, ok := columns[field.column]
+		if !ok {
+			continue
+		}
+		if *field.target, err = parseMetric(field.column, record[index]); err != nil {
+			return rule, err
+		}
+	}
+
+	ints := []struct {
+		column string
+		target *int
+	}{
+		{"antecedent_count", &rule.AntecedentCount},
+		{"itemset_count", &rule.ItemsetCount},
+		{"transaction_count", &rule.TransactionCount},
+	}
+	for _, field := range ints {
+		index, ok := columns[field.column]
+		if !ok {
+			continue
+		}
+		value := strings.TrimSpace(record[index])
+		if *field.target, err = strconv.Atoi(value); err != nil {
+			return rule, fmt.Errorf("%w: invalid %s value %q", ErrInvalidFormat, field.column, value)
+		}
+	}
+
+	return rule, nil
+}
+
+// parseMetric parses a metric cell in any of the output package's non-finite styles:
+// inf, -inf and nan as written, and an empty or null cell as the value the column can
+// take when it is undefined, +Inf for a conviction (a rule with confidence 1) and NaN
+// for the other metrics
+func parseMetric(column, cell string) (float64, error) {
+	value := strings.TrimSpace(cell)
+	if value == "" || value == "null" {
+		if column == "conviction" {
+			return math.Inf(1), nil
+		}
+		return math.NaN(), nil
+	}
+	metric, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s value %q", ErrInvalidFormat, column, value)
+	}
+	return metric, nil
+}
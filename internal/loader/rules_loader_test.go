@@ -0,0 +1,68 @@
+package loader
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+	"github.com/RiceaRaul/AprioriGO/internal/output"
+)
+
+func TestRulesRoundTripNonFinite(t *testing.T) {
+	rules := []models.AssociationRule{
+		{
+			Antecedent: []string{"milk"}, Consequent: []string{"bread"},
+			Support: 0.25, Confidence: 1, Lift: 2, LeverageMetric: 0.125, ConvictionMetric: math.Inf(1),
+			AntecedentCount: 1, ItemsetCount: 1, TransactionCount: 4,
+		},
+		{
+			Antecedent: []string{"eggs"}, Consequent: []string{"milk"},
+			Support: 0.25, Confidence: 0.5, Lift: 1, LeverageMetric: 0, ConvictionMetric: 1,
+			AntecedentCount: 2, ItemsetCount: 1, TransactionCount: 4,
+		},
+	}
+
+	for _, style := range []output.NonFinite{output.NonFiniteInf, output.NonFiniteEmpty, output.NonFiniteNull} {
+		t.Run(string(style), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.csv")
+			if err := output.SaveRulesToCSVWithOptions(rules, path, output.CSVOptions{NonFinite: style}); err != nil {
+				t.Fatalf("SaveRulesToCSVWithOptions: %v", err)
+			}
+
+			loaded, err := LoadRulesFromCSV(path)
+			if err != nil {
+				t.Fatalf("LoadRulesFromCSV: %v", err)
+			}
+			if len(loaded) != len(rules) {
+				t.Fatalf("loaded %d rules, want %d", len(loaded), len(rules))
+			}
+			for i, rule := range loaded {
+				want := rules[i]
+				if models.ItemsetKey(rule.Antecedent) != models.ItemsetKey(want.Antecedent) ||
+					models.ItemsetKey(rule.Consequent) != models.ItemsetKey(want.Consequent) {
+					t.Errorf("rule %d: got %v => %v, want %v => %v", i, rule.Antecedent, rule.Consequent, want.Antecedent, want.Consequent)
+				}
+				if rule.Confidence != want.Confidence || rule.Lift != want.Lift || rule.ConvictionMetric != want.ConvictionMetric {
+					t.Errorf("rule %d: got confidence %v, lift %v, conviction %v, want %v, %v, %v",
+						i, rule.Confidence, rule.Lift, rule.ConvictionMetric, want.Confidence, want.Lift, want.ConvictionMetric)
+				}
+				if rule.TransactionCount != want.TransactionCount {
+					t.Errorf("rule %d: got transaction count %d, want %d", i, rule.TransactionCount, want.TransactionCount)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMetricUndefined(t *testing.T) {
+	for _, cell := range []string{"", "null", "nan"} {
+		value, err := parseMetric("lift", cell)
+		if err != nil || !math.IsNaN(value) {
+			t.Errorf("parseMetric(lift, %q) = %v, %v, want NaN", cell, value, err)
+		}
+	}
+	if _, err := parseMetric("lift", "n/a"); err == nil {
+		t.Error("parseMetric accepted n/a")
+	}
+}
@@ -0,0 +1,134 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SessionOptions configures how an event log is grouped into sessions
+type SessionOptions struct {
+	LoadOptions
+
+	// Column indices of the timestamp, user and item fields
+	TimestampColumn int
+	UserColumn      int
+	ItemColumn      int
+
+	// Window is the longest gap between two events of a user that still belong to the
+	// same session. A longer pause starts a new session.
+	Window time.Duration
+
+	// TimeLayout is the time.Parse layout of the timestamps. Empty accepts RFC 3339
+	// timestamps or Unix seconds.
+	TimeLayout string
+}
+
+// sessionEvent is one row of an event log
+type sessionEvent struct {
+	at   time.Time
+	item string
+}
+
+// LoadSessionsFromCSV loads an event log such as "timestamp,user,item" and turns each
+// user session into a transaction, dated by its first event in the dataset's
+// Timestamps. A header row is skipped when its timestamp does not parse.
+func LoadSessionsFromCSV(filePath string, opts SessionOptions) (*models.Dataset, *LoadReport, error) {
+	defer models.StartPhase(opts.Observer, models.PhaseLoad)()
+
+	if opts.Window <= 0 {
+		return nil, nil, fmt.Errorf("invalid session window %v: must be positive", opts.Window)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	r, err := textReader(file, opts.Encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Allow variable number of fields
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+
+	transform := newItemTransformer(opts.LoadOptions)
+	columns := max(opts.TimestampColumn, opts.UserColumn, opts.ItemColumn) + 1
+
+	events := make(map[string][]sessionEvent)
+	for i, record := range records {
+		if len(record) < columns {
+			fmt.Printf("Skipping invalid row %d: fewer than %d columns\n", i+1, columns)
+			continue
+		}
+
+		at, err := parseTimestamp(strings.TrimSpace(record[opts.TimestampColumn]), opts.TimeLayout)
+		if err != nil {
+			if i > 0 {
+				fmt.Printf("Skipping invalid row %d: %v\n", i+1, err)
+			}
+			continue // The first row is most likely a header
+		}
+
+		user := strings.TrimSpace(record[opts.UserColumn])
+		item := transform.apply(strings.TrimSpace(record[opts.ItemColumn]))
+		if user == "" || item == "" {
+			continue
+		}
+
+		events[user] = append(events[user], sessionEvent{at: at, item: item})
+	}
+
+	// Split each user's events into sessions at gaps longer than the window, dating
+	// each session by its first event
+	basketMap := make(map[string][]string)
+	basketTimes := make(map[string]time.Time)
+	for user, userEvents := range events {
+		sort.SliceStable(userEvents, func(a, b int) bool { return userEvents[a].at.Before(userEvents[b].at) })
+
+		session := 0
+		for i, event := range userEvents {
+			if i > 0 && event.at.Sub(userEvents[i-1].at) > opts.Window {
+				session++
+			}
+			key := user + "\x00" + strconv.Itoa(session)
+			if _, ok := basketTimes[key]; !ok {
+				basketTimes[key] = event.at
+			}
+			basketMap[key] = append(basketMap[key], event.item)
+		}
+	}
+
+	report := &LoadReport{MergedItems: transform.merged(), DroppedItems: transform.droppedNames(), SkippedNulls: transform.skippedNulls()}
+	dataset := buildTimedDataset(basketMap, basketTimes, opts.LoadOptions, report)
+	if len(dataset.Transactions) == 0 {
+		return nil, nil, fmt.Errorf("error reading CSV: %w", ErrEmptyDataset)
+	}
+
+	return dataset, report, nil
+}
+
+// parseTimestamp parses a timestamp with the given layout, or as RFC 3339 or Unix
+// seconds when no layout is given
+func parseTimestamp(value, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, value)
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
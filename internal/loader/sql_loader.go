@@ -0,0 +1,67 @@
+package loader
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// LoadFromSQL loads transactions by running a query that returns basket and item
+// columns, in that order. The caller opens the *sql.DB with a driver of their
+// choice, so no database driver is linked into this package.
+func LoadFromSQL(db *sql.DB, query string, args ...any) (*models.Dataset, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error running query: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error reading columns: %w", err)
+	}
+	if len(columns) < 2 {
+		return nil, fmt.Errorf("%w: query must return basket and item columns, got %d columns", ErrInvalidFormat, len(columns))
+	}
+
+	// Extra columns are scanned and ignored
+	values := make([]any, len(columns))
+	var basket, item sql.NullString
+	values[0], values[1] = &basket, &item
+	for i := 2; i < len(values); i++ {
+		values[i] = new(sql.RawBytes)
+	}
+
+	// Group by basket
+	basketMap := make(map[string][]string)
+	for rows.Next() {
+		if err := rows.Scan(values...); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		if !basket.Valid || !item.Valid {
+			continue
+		}
+
+		basketID := strings.TrimSpace(basket.String)
+		itemName := strings.TrimSpace(item.String)
+		if basketID == "" || itemName == "" {
+			continue
+		}
+
+		basketMap[basketID] = append(basketMap[basketID], itemName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rows: %w", err)
+	}
+
+	dataset := buildDataset(basketMap, LoadOptions{}, nil)
+	if len(dataset.Transactions) == 0 {
+		return nil, fmt.Errorf("error reading rows: %w", ErrEmptyDataset)
+	}
+
+	return dataset, nil
+}
@@ -0,0 +1,55 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// LoadTaxonomyFromCSV loads an item to category map from a CSV file with item and
+// category columns. A header row is skipped when present.
+func LoadTaxonomyFromCSV(filePath string) (models.Taxonomy, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	r, err := textReader(file, EncodingUTF8)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Allow variable number of fields
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+
+	taxonomy := make(models.Taxonomy)
+	for i, record := range records {
+		if len(record) < 2 {
+			return nil, fmt.Errorf("error parsing row %d: %w: fewer than 2 columns", i+1, ErrInvalidFormat)
+		}
+
+		item := strings.TrimSpace(record[0])
+		category := strings.TrimSpace(record[1])
+		if i == 0 && strings.EqualFold(item, "item") && strings.EqualFold(category, "category") {
+			continue
+		}
+		if item == "" || category == "" {
+			continue
+		}
+
+		if existing, ok := taxonomy[item]; ok && existing != category {
+			return nil, fmt.Errorf("error parsing row %d: %w: item %q is in both %q and %q", i+1, ErrInvalidFormat, item, existing, category)
+		}
+		taxonomy[item] = category
+	}
+
+	return taxonomy, nil
+}
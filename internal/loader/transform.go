@@ -0,0 +1,99 @@
+package loader
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// itemTransformer applies the load options to raw item names and keeps track of
+// how many distinct raw names ended up folded together or dropped
+type itemTransformer struct {
+	opts      LoadOptions
+	raw       map[string]bool // Raw names kept under some canonical name
+	canonical map[string]bool
+	dropped   map[string]bool // Raw names the transformation turned into ""
+	nulls     map[string]int  // Occurrences of each null token skipped
+}
+
+// newItemTransformer creates a transformer for the given load options
+func newItemTransformer(opts LoadOptions) *itemTransformer {
+	nulls := make(map[string]int, len(opts.NullTokens))
+	for _, token := range opts.NullTokens {
+		nulls[token] = 0
+	}
+	return &itemTransformer{
+		opts:      opts,
+		raw:       make(map[string]bool),
+		canonical: make(map[string]bool),
+		dropped:   make(map[string]bool),
+		nulls:     nulls,
+	}
+}
+
+// apply converts a raw item name into its canonical form, normalizing it first
+// and then passing it through the ItemTransform hook. Null tokens and an empty
+// result drop the item.
+func (t *itemTransformer) apply(item string) string {
+	if t.skipNull(item) {
+		return ""
+	}
+	if item == "" || (!t.opts.NormalizeItems && t.opts.ItemTransform == nil) {
+		return item
+	}
+
+	canonical := item
+	if t.opts.NormalizeItems {
+		canonical = normalizeItem(canonical)
+	}
+	if t.opts.ItemTransform != nil && canonical != "" {
+		canonical = t.opts.ItemTransform(canonical)
+	}
+
+	if canonical == "" {
+		t.dropped[item] = true
+		return ""
+	}
+	t.raw[item] = true
+	t.canonical[canonical] = true
+	return canonical
+}
+
+// merged returns how many distinct raw names were folded into another name, leaving
+// out the dropped ones
+func (t *itemTransformer) merged() int {
+	return len(t.raw) - len(t.canonical)
+}
+
+// droppedNames returns how many distinct raw names were dropped by normalizing them to
+// nothing or by ItemTransform returning ""
+func (t *itemTransformer) droppedNames() int {
+	return len(t.dropped)
+}
+
+// skipNull reports whether a value is one of the null tokens, counting it if so
+func (t *itemTransformer) skipNull(value string) bool {
+	count, ok := t.nulls[value]
+	if ok {
+		t.nulls[value] = count + 1
+	}
+	return ok
+}
+
+// skippedNulls returns how many times each null token was skipped, leaving out the
+// tokens that never occurred
+func (t *itemTransformer) skippedNulls() map[string]int {
+	skipped := make(map[string]int)
+	for token, count := range t.nulls {
+		if count > 0 {
+			skipped[token] = count
+		}
+	}
+	return skipped
+}
+
+// normalizeItem lowercases an item name, brings it to Unicode normalization form C, so
+// precomposed and decomposed accents compare equal, and collapses internal whitespace
+func normalizeItem(item string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(strings.ToLower(item))), " ")
+}
@@ -0,0 +1,54 @@
+package loader
+
+import "testing"
+
+func TestNormalizeItem(t *testing.T) {
+	tests := []struct {
+		item, want string
+	}{
+		{"Milk", "milk"},
+		{"  WHOLE   milk ", "whole milk"},
+		{"Café", "café"},  // Precomposed é
+		{"Café", "café"}, // e followed by a combining acute accent
+		{"CAFÉ", "café"}, // Uppercase E with a combining accent
+		{"Ångström", "ångström"},
+	}
+	for _, test := range tests {
+		if got := normalizeItem(test.item); got != test.want {
+			t.Errorf("normalizeItem(%q) = %q, want %q", test.item, got, test.want)
+		}
+	}
+}
+
+func TestNormalizeItemsMergesUnicodeForms(t *testing.T) {
+	transform := newItemTransformer(LoadOptions{NormalizeItems: true})
+	for _, item := range []string{"Café", "café", "CAFÉ"} {
+		if got := transform.apply(item); got != "café" {
+			t.Errorf("apply(%q) = %q, want %q", item, got, "café")
+		}
+	}
+	if merged := transform.merged(); merged != 2 {
+		t.Errorf("merged() = %d, want 2", merged)
+	}
+}
+
+func TestDroppedItemsAreNotMerged(t *testing.T) {
+	transform := newItemTransformer(LoadOptions{
+		NormalizeItems: true,
+		ItemTransform: func(item string) string {
+			if item == "bag" {
+				return ""
+			}
+			return item
+		},
+	})
+	for _, item := range []string{"Milk", "milk", "bag", "Bag", "bread"} {
+		transform.apply(item)
+	}
+	if merged := transform.merged(); merged != 1 {
+		t.Errorf("merged() = %d, want 1 for Milk folded into milk", merged)
+	}
+	if dropped := transform.droppedNames(); dropped != 2 {
+		t.Errorf("droppedNames() = %d, want 2 for bag and Bag", dropped)
+	}
+}
@@ -0,0 +1,71 @@
+package loader
+
+import (
+	"fmt"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Warning codes reported by DatasetValidate
+const (
+	WarnNoTransactions        = "no_transactions"
+	WarnEmptyTransactions     = "empty_transactions"
+	WarnSingleItemTransaction = "single_item_transactions"
+	WarnUniversalItem         = "universal_item"
+	WarnLargeItemUniverse     = "large_item_universe"
+)
+
+// Warning describes a data anomaly found before mining
+type Warning struct {
+	Code    string
+	Message string
+}
+
+// DatasetValidate checks a dataset for anomalies that would otherwise only show up as
+// odd metrics in the results. An empty result means no problems were found.
+func DatasetValidate(ds *models.Dataset) []Warning {
+	warnings := make([]Warning, 0)
+
+	total := len(ds.Transactions)
+	if total == 0 {
+		return append(warnings, Warning{WarnNoTransactions, "dataset has no transactions"})
+	}
+
+	empty, single := 0, 0
+	counts := make(map[string]int)
+	for _, transaction := range ds.Transactions {
+		switch len(transaction) {
+		case 0:
+			empty++
+		case 1:
+			single++
+		}
+		for _, item := range transaction {
+			counts[item]++
+		}
+	}
+
+	if empty > 0 {
+		warnings = append(warnings, Warning{WarnEmptyTransactions,
+			fmt.Sprintf("%d of %d transactions are empty", empty, total)})
+	}
+	if single > 0 {
+		warnings = append(warnings, Warning{WarnSingleItemTransaction,
+			fmt.Sprintf("%d of %d transactions have a single item and contribute nothing to rules", single, total)})
+	}
+
+	for _, item := range ds.UniqueItems {
+		if counts[item] == total {
+			warnings = append(warnings, Warning{WarnUniversalItem,
+				fmt.Sprintf("item %q appears in every transaction, so rules predicting it have infinite conviction", item)})
+		}
+	}
+
+	if len(ds.UniqueItems) > total {
+		warnings = append(warnings, Warning{WarnLargeItemUniverse,
+			fmt.Sprintf("%d unique items for only %d transactions; most items will be too rare to be frequent",
+				len(ds.UniqueItems), total)})
+	}
+
+	return warnings
+}
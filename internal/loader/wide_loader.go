@@ -0,0 +1,86 @@
+package loader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// WideOptions configures how wide basket rows are read
+type WideOptions struct {
+	LoadOptions
+
+	// ItemSeparator splits an item cell holding several items, such as "milk;bread",
+	// into one item each. Empty treats every cell as a single item.
+	ItemSeparator string
+}
+
+// LoadFromWideCSV loads a CSV with one basket per row: the basket ID in the first
+// column and its items in the remaining columns, e.g. "basket_id,item1,item2,...".
+// Rows may have any number of item cells; empty cells, such as the padding of shorter
+// rows, and the null tokens of the options are skipped. Rows sharing a basket ID are
+// merged, and a header row is skipped as in LoadFromCSV.
+func LoadFromWideCSV(filePath string, opts WideOptions) (*models.Dataset, *LoadReport, error) {
+	defer models.StartPhase(opts.Observer, models.PhaseLoad)()
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	r, err := textReader(file, opts.Encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Rows have as many item cells as their basket has items
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+
+	transform := newItemTransformer(opts.LoadOptions)
+	basketMap := make(map[string][]string)
+	for i, record := range records {
+		if i == 0 && looksLikeHeader(record) {
+			continue
+		}
+
+		basket := strings.TrimSpace(record[0])
+		if basket == "" {
+			continue
+		}
+		for _, cell := range record[1:] {
+			for _, item := range splitItemCell(cell, opts.ItemSeparator) {
+				if item = transform.apply(item); item != "" {
+					basketMap[basket] = append(basketMap[basket], item)
+				}
+			}
+		}
+	}
+
+	report := &LoadReport{MergedItems: transform.merged(), DroppedItems: transform.droppedNames(), SkippedNulls: transform.skippedNulls()}
+	dataset := buildDataset(basketMap, opts.LoadOptions, report)
+	if len(dataset.Transactions) == 0 {
+		return nil, nil, fmt.Errorf("error reading CSV: %w", ErrEmptyDataset)
+	}
+
+	return dataset, report, nil
+}
+
+// splitItemCell splits a cell on the separator, trimming each item
+func splitItemCell(cell, separator string) []string {
+	if separator == "" {
+		return []string{strings.TrimSpace(cell)}
+	}
+	items := strings.Split(cell, separator)
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items
+}
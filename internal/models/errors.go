@@ -0,0 +1,8 @@
+package models
+
+import (
+	"errors"
+)
+
+// ErrEmptyDataset is returned when a dataset has no transactions to work with
+var ErrEmptyDataset = errors.New("dataset has no transactions")
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// ruleFields is AssociationRule without its methods, so the JSON methods can encode
+// the remaining fields with their struct tags
+type ruleFields AssociationRule
+
+// ruleJSON replaces the rule metrics that can be non-finite, which encoding/json
+// rejects, with pointers that encode as null or are left out
+type ruleJSON struct {
+	ruleFields
+	ConvictionMetric  *float64 `json:"conviction"`
+	SegmentConfidence *float64 `json:"segment_confidence,omitempty"`
+	SegmentLift       *float64 `json:"segment_lift,omitempty"`
+}
+
+// MarshalJSON encodes a rule with the field names of its struct tags. An infinite
+// conviction, from a rule that always holds, is written as null, and segment metrics
+// that are unset or undefined are left out.
+func (r AssociationRule) MarshalJSON() ([]byte, error) {
+	record := ruleJSON{ruleFields: ruleFields(r), ConvictionMetric: finiteOrNil(r.ConvictionMetric)}
+	if r.SegmentConfidence != 0 {
+		record.SegmentConfidence = finiteOrNil(r.SegmentConfidence)
+	}
+	if r.SegmentLift != 0 {
+		record.SegmentLift = finiteOrNil(r.SegmentLift)
+	}
+	return json.Marshal(record)
+}
+
+// UnmarshalJSON decodes a rule written by MarshalJSON, reading a null or missing
+// conviction back as +Inf
+func (r *AssociationRule) UnmarshalJSON(data []byte) error {
+	var record ruleJSON
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+
+	*r = AssociationRule(record.ruleFields)
+	r.ConvictionMetric = math.Inf(1)
+	if record.ConvictionMetric != nil {
+		r.ConvictionMetric = *record.ConvictionMetric
+	}
+	if record.SegmentConfidence != nil {
+		r.SegmentConfidence = *record.SegmentConfidence
+	}
+	if record.SegmentLift != nil {
+		r.SegmentLift = *record.SegmentLift
+	}
+	return nil
+}
+
+// finiteOrNil returns a pointer to v, or nil when v is infinite or NaN
+func finiteOrNil(v float64) *float64 {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
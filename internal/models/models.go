@@ -1,29 +1,195 @@
 package models
 
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 // Transaction represents a set of items in a basket
 type Transaction []string
 
 // FrequentItemset represents a set of items that appear together with their support
 type FrequentItemset struct {
-	Items   []string
-	Support float64
-	Length  int
+	Items   []string `json:"items"`
+	Support float64  `json:"support"`
+	Length  int      `json:"length"`
+	Count   int      `json:"support_count"` // Number of transactions containing the itemset
+
+	// Optional confidence interval around Support, set by interval estimation
+	SupportLower float64 `json:"support_lower,omitempty"`
+	SupportUpper float64 `json:"support_upper,omitempty"`
 }
 
-// AssociationRule represents a rule with antecedent -> consequent with metrics
+// AssociationRule represents a rule with antecedent -> consequent with metrics.
+// Its JSON encoding writes an infinite conviction as null; see MarshalJSON.
 type AssociationRule struct {
-	Antecedent       []string
-	Consequent       []string
-	Support          float64
-	Confidence       float64
-	Lift             float64
-	LeverageMetric   float64
-	ConvictionMetric float64
+	Antecedent       []string `json:"antecedent"`
+	Consequent       []string `json:"consequent"`
+	Support          float64  `json:"support"`
+	Confidence       float64  `json:"confidence"`
+	Lift             float64  `json:"lift"`
+	LeverageMetric   float64  `json:"leverage"`
+	ConvictionMetric float64  `json:"conviction"`
+	AntecedentCount  int      `json:"antecedent_count"`        // Transactions containing the antecedent
+	ItemsetCount     int      `json:"itemset_count"`           // Transactions containing antecedent and consequent
+	TransactionCount int      `json:"transaction_count"`       // Total transactions in the dataset
+	RevenueScore     float64  `json:"revenue_score,omitempty"` // Expected revenue uplift, set when prices are supplied
+
+	// AntecedentSupport is the fraction of transactions containing the antecedent
+	AntecedentSupport float64 `json:"antecedent_support,omitempty"`
+
+	// ConsequentSupport is the base rate of the consequent, the confidence of the rule
+	// {} => consequent, which Lift compares the rule's confidence against
+	ConsequentSupport float64 `json:"consequent_support,omitempty"`
+
+	// Confidence and lift within a subpopulation of the transactions, set when a
+	// segment is supplied; NaN when the segment lacks the antecedent or consequent
+	SegmentConfidence float64 `json:"segment_confidence,omitempty"`
+	SegmentLift       float64 `json:"segment_lift,omitempty"`
+
+	// Score is the weighted composite of normalized metrics set by ScoreRules
+	Score float64 `json:"score,omitempty"`
 }
 
+// Taxonomy maps each item to its parent category
+type Taxonomy map[string]string
+
 // Dataset contains the transaction data and metadata
 type Dataset struct {
 	Transactions []Transaction
 	UniqueItems  []string
 	ItemsMap     map[string]bool
+
+	// Timestamps optionally holds the time of each transaction, in the same order as
+	// Transactions. Loaders without a time column leave it nil.
+	Timestamps []time.Time
+
+	// Quantities optionally holds, per transaction, how many times each of its items
+	// occurred in the input, for loads that keep multiplicities. Transactions still
+	// list every item once, so mining support counts a basket with three of an item
+	// once; for a quantity-based measure see algorithm.QuantitySupport.
+	Quantities []map[string]int
+
+	// Sequences optionally holds, per transaction, its items in the order they were
+	// read, repeats included, for loads that keep event order. Transactions are sorted
+	// and deduplicated, so order-aware analyses read this instead.
+	Sequences [][]string
+
+	indexOnce  sync.Once
+	indexReady atomic.Bool
+	index      map[string][]int
+}
+
+// Quantity returns how many times item occurred in transaction i, or 1 when the
+// dataset does not keep multiplicities. The item is assumed to be in the transaction.
+func (d *Dataset) Quantity(i int, item string) int {
+	if d.Quantities == nil {
+		return 1
+	}
+	return d.Quantities[i][item]
+}
+
+// InvertedIndex returns a map from each item to the sorted indices of the transactions
+// containing it. The index is built on first use and shared by every later caller,
+// so Transactions must not be modified once it has been requested.
+func (d *Dataset) InvertedIndex() map[string][]int {
+	d.indexOnce.Do(func() {
+		d.index = make(map[string][]int, len(d.ItemsMap))
+		for i, transaction := range d.Transactions {
+			for _, item := range transaction {
+				d.index[item] = append(d.index[item], i)
+			}
+		}
+		d.indexReady.Store(true)
+	})
+	return d.index
+}
+
+// HasInvertedIndex reports whether the inverted index has already been built, so
+// callers can use it when it is free without paying to build it
+func (d *Dataset) HasInvertedIndex() bool {
+	return d.indexReady.Load()
+}
+
+// NewDataset builds a dataset from transactions, deriving the unique item metadata
+func NewDataset(transactions []Transaction) *Dataset {
+	dataset := &Dataset{
+		Transactions: transactions,
+		ItemsMap:     make(map[string]bool),
+	}
+
+	for _, transaction := range transactions {
+		for _, item := range transaction {
+			dataset.ItemsMap[item] = true
+		}
+	}
+
+	dataset.UniqueItems = make([]string, 0, len(dataset.ItemsMap))
+	for item := range dataset.ItemsMap {
+		dataset.UniqueItems = append(dataset.UniqueItems, item)
+	}
+	sort.Strings(dataset.UniqueItems)
+
+	return dataset
+}
+
+// itemKeyDelimiter separates the items of an ItemsetKey. It cannot occur in item names
+// read from text sources, unlike "," which is legal inside a quoted CSV field.
+const itemKeyDelimiter = "\x00"
+
+// ItemsetKey returns an identity for a set of items that does not depend on their
+// order, for comparing itemsets and using them as map keys. Sorted input, the common
+// case, is joined without copying.
+func ItemsetKey(items []string) string {
+	if !sort.StringsAreSorted(items) {
+		sorted := make([]string, len(items))
+		copy(sorted, items)
+		sort.Strings(sorted)
+		items = sorted
+	}
+	return strings.Join(items, itemKeyDelimiter)
+}
+
+// CanonicalItemsets returns the itemsets with the items of each sorted and with any
+// itemset that repeats an earlier one in another order dropped, so {b,a} and {a,b}
+// are looked up as one. The input is returned unchanged when it is already canonical.
+func CanonicalItemsets(itemsets []FrequentItemset) []FrequentItemset {
+	seen := make(map[string]bool, len(itemsets))
+	canonical := true
+	for _, itemset := range itemsets {
+		if !sort.StringsAreSorted(itemset.Items) {
+			canonical = false
+			break
+		}
+		key := ItemsetKey(itemset.Items)
+		if seen[key] {
+			canonical = false
+			break
+		}
+		seen[key] = true
+	}
+	if canonical {
+		return itemsets
+	}
+
+	result := make([]FrequentItemset, 0, len(itemsets))
+	clear(seen)
+	for _, itemset := range itemsets {
+		items := make([]string, len(itemset.Items))
+		copy(items, itemset.Items)
+		sort.Strings(items)
+
+		key := ItemsetKey(items)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		itemset.Items = items
+		result = append(result, itemset)
+	}
+	return result
 }
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Phases reported to an Observer
+const (
+	PhaseLoad     = "load"
+	PhaseItemsets = "itemsets"
+	PhaseRules    = "rules"
+)
+
+// Observer is notified around the phases of a run, such as loading the data, mining
+// itemsets and generating rules, e.g. to export timing metrics from a service embedding
+// the miner. The methods are called on the goroutine running the phase, so they must be
+// safe for concurrent use when phases run in parallel, as with MineByGroup.
+type Observer interface {
+	OnPhaseStart(phase string)
+	OnPhaseEnd(phase string, elapsed time.Duration)
+}
+
+// StartPhase tells the observer that a phase starts and returns the function that
+// reports its end. A nil observer is allowed and is not called.
+func StartPhase(observer Observer, phase string) func() {
+	if observer == nil {
+		return func() {}
+	}
+	observer.OnPhaseStart(phase)
+	start := time.Now()
+	return func() {
+		observer.OnPhaseEnd(phase, time.Since(start))
+	}
+}
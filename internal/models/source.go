@@ -0,0 +1,11 @@
+package models
+
+// TransactionSource yields transactions one at a time, so they can be mined without
+// holding them all in memory. Reset rewinds the source to its first transaction and
+// is called before every pass; Next returns false once the pass is over, after which
+// Err reports whether it ended because of an error.
+type TransactionSource interface {
+	Reset() error
+	Next() ([]string, bool)
+	Err() error
+}
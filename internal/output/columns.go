@@ -0,0 +1,144 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// ruleColumn is a metric column of a rules CSV file
+type ruleColumn struct {
+	name  string
+	value func(rule models.AssociationRule, nonFinite NonFinite) string
+}
+
+// DefaultRuleColumns are the metric columns written when no selection is given
+var DefaultRuleColumns = []string{"support", "confidence", "lift", "leverage", "conviction",
+	"antecedent_count", "itemset_count", "transaction_count"}
+
+// RuleLayout is a predefined column layout of a rules CSV file
+type RuleLayout string
+
+const (
+	RuleLayoutDefault RuleLayout = "default"
+	RuleLayoutMLxtend RuleLayout = "mlxtend"
+)
+
+// mlxtendRuleColumns are the metric columns of the DataFrame returned by mlxtend's
+// association_rules, in its order
+var mlxtendRuleColumns = []string{"antecedent_support", "consequent_support", "support", "confidence",
+	"lift", "leverage", "conviction"}
+
+// ParseRuleLayout converts a layout name to a RuleLayout
+func ParseRuleLayout(name string) (RuleLayout, error) {
+	switch layout := RuleLayout(name); layout {
+	case RuleLayoutDefault, RuleLayoutMLxtend:
+		return layout, nil
+	default:
+		return "", fmt.Errorf("unknown rule layout %q, must be default or mlxtend", name)
+	}
+}
+
+// ruleColumns holds every selectable metric column by name
+var ruleColumns = map[string]func(rule models.AssociationRule, nonFinite NonFinite) string{
+	"support":            floatColumn(func(rule models.AssociationRule) float64 { return rule.Support }),
+	"confidence":         floatColumn(func(rule models.AssociationRule) float64 { return rule.Confidence }),
+	"lift":               floatColumn(func(rule models.AssociationRule) float64 { return rule.Lift }),
+	"leverage":           floatColumn(func(rule models.AssociationRule) float64 { return rule.LeverageMetric }),
+	"conviction":         floatColumn(func(rule models.AssociationRule) float64 { return rule.ConvictionMetric }),
+	"antecedent_count":   intColumn(func(rule models.AssociationRule) int { return rule.AntecedentCount }),
+	"itemset_count":      intColumn(func(rule models.AssociationRule) int { return rule.ItemsetCount }),
+	"transaction_count":  intColumn(func(rule models.AssociationRule) int { return rule.TransactionCount }),
+	"revenue_score":      floatColumn(func(rule models.AssociationRule) float64 { return rule.RevenueScore }),
+	"segment_confidence": floatColumn(func(rule models.AssociationRule) float64 { return rule.SegmentConfidence }),
+	"segment_lift":       floatColumn(func(rule models.AssociationRule) float64 { return rule.SegmentLift }),
+	"antecedent_support": floatColumn(func(rule models.AssociationRule) float64 { return rule.AntecedentSupport }),
+	"consequent_support": floatColumn(func(rule models.AssociationRule) float64 { return rule.ConsequentSupport }),
+	"score":              floatColumn(func(rule models.AssociationRule) float64 { return rule.Score }),
+}
+
+// floatColumn formats a float metric of a rule
+func floatColumn(metric func(rule models.AssociationRule) float64) func(models.AssociationRule, NonFinite) string {
+	return func(rule models.AssociationRule, nonFinite NonFinite) string {
+		return nonFinite.format(metric(rule))
+	}
+}
+
+// intColumn formats a count of a rule
+func intColumn(count func(rule models.AssociationRule) int) func(models.AssociationRule, NonFinite) string {
+	return func(rule models.AssociationRule, _ NonFinite) string {
+		return strconv.Itoa(count(rule))
+	}
+}
+
+// ValidateRuleColumns checks a column selection without writing anything
+func ValidateRuleColumns(names []string) error {
+	_, err := selectRuleColumns(names)
+	return err
+}
+
+// layoutRuleColumns resolves the columns of a layout. The mlxtend layout has fixed
+// columns, headed with mlxtend's names such as "antecedent support", so it cannot be
+// combined with a column selection; the empty and default layouts use the selection.
+func layoutRuleColumns(layout RuleLayout, names []string) ([]ruleColumn, error) {
+	switch layout {
+	case "", RuleLayoutDefault:
+		return selectRuleColumns(names)
+	case RuleLayoutMLxtend:
+		if names != nil {
+			return nil, fmt.Errorf("a column selection cannot be combined with the %s layout", layout)
+		}
+		columns, err := selectRuleColumns(mlxtendRuleColumns)
+		if err != nil {
+			return nil, err
+		}
+		for i := range columns {
+			columns[i].name = strings.ReplaceAll(columns[i].name, "_", " ")
+		}
+		return columns, nil
+	default:
+		return nil, fmt.Errorf("unknown rule layout %q", layout)
+	}
+}
+
+// selectRuleColumns resolves column names, defaulting to DefaultRuleColumns
+func selectRuleColumns(names []string) ([]ruleColumn, error) {
+	if names == nil {
+		names = DefaultRuleColumns
+	}
+
+	columns := make([]ruleColumn, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		value, ok := ruleColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownColumn, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate rule column %q", name)
+		}
+		seen[name] = true
+		columns = append(columns, ruleColumn{name, value})
+	}
+	return columns, nil
+}
+
+// ruleHeader builds the header row for the selected columns
+func ruleHeader(columns []ruleColumn) []string {
+	header := []string{"antecedents", "consequents"}
+	for _, column := range columns {
+		header = append(header, column.name)
+	}
+	return header
+}
+
+// ruleRecord builds the CSV record of a rule for the selected columns
+func ruleRecord(rule models.AssociationRule, columns []ruleColumn, style ItemStyle, nonFinite NonFinite) []string {
+	record := []string{style.format(rule.Antecedent), style.format(rule.Consequent)}
+	for _, column := range columns {
+		record = append(record, column.value(rule, nonFinite))
+	}
+	return record
+}
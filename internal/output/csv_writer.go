@@ -3,89 +3,204 @@ package output
 import (
 	"encoding/csv"
 	"fmt"
-	"math"
+	"io"
 	"os"
-	"strings"
 
 	"github.com/RiceaRaul/AprioriGO/internal/models"
 )
 
+// flushInterval is how many records are buffered before being flushed to disk
+const flushInterval = 1000
+
+// utf8BOM marks a file as UTF-8 for spreadsheet applications such as Excel
+const utf8BOM = "\ufeff"
+
+// CSVOptions controls how CSV output files are written
+type CSVOptions struct {
+	// BOM prepends a UTF-8 byte order mark so Excel decodes non-ASCII item names correctly
+	BOM bool
+
+	// Columns selects the metric columns of a rules file and their order, from the
+	// names in DefaultRuleColumns plus revenue_score, antecedent_support,
+	// consequent_support, segment_confidence, segment_lift and score. The antecedents
+	// and consequents columns always come first. Nil writes DefaultRuleColumns.
+	Columns []string
+
+	// Layout selects a predefined column layout for rules files. RuleLayoutMLxtend
+	// writes the columns of mlxtend's association_rules, so the file can replace its
+	// output in existing pipelines, and must be used with nil Columns.
+	Layout RuleLayout
+
+	// OmitSupportCount drops the support_count column, the number of transactions
+	// containing each itemset, from itemsets files
+	OmitSupportCount bool
+
+	// ItemStyle renders the item list columns of rules and itemsets files. The
+	// empty style writes brace-wrapped lists.
+	ItemStyle ItemStyle
+
+	// NonFinite renders infinite and NaN metrics, the same way in every column. The
+	// zero value writes inf, -inf and nan.
+	NonFinite NonFinite
+}
+
 // SaveRulesToCSV saves association rules to a CSV file
 func SaveRulesToCSV(rules []models.AssociationRule, filePath string) error {
+	return SaveRulesToCSVWithOptions(rules, filePath, CSVOptions{})
+}
+
+// SaveRulesToCSVWithOptions saves association rules to a CSV file using the given options
+func SaveRulesToCSVWithOptions(rules []models.AssociationRule, filePath string, opts CSVOptions) error {
 	file, err := os.Create(filePath)
 	if err != nil {
-		return fmt.Errorf("error creating output file: %v", err)
+		return fmt.Errorf("error creating output file: %w", err)
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{"antecedents", "consequents", "support", "confidence", "lift", "leverage", "conviction"}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("error writing header: %v", err)
+	writer, err := NewCSVRuleWriter(file, opts)
+	if err != nil {
+		return err
 	}
 
-	// Write rules
 	for _, rule := range rules {
-		antecedentStr := "{" + strings.Join(rule.Antecedent, ",") + "}"
-		consequentStr := "{" + strings.Join(rule.Consequent, ",") + "}"
-
-		conviction := fmt.Sprintf("%.6f", rule.ConvictionMetric)
-		if math.IsInf(rule.ConvictionMetric, 1) {
-			conviction = "inf"
+		if err := writer.Write(rule); err != nil {
+			return err
 		}
+	}
 
-		record := []string{
-			antecedentStr,
-			consequentStr,
-			fmt.Sprintf("%.6f", rule.Support),
-			fmt.Sprintf("%.6f", rule.Confidence),
-			fmt.Sprintf("%.6f", rule.Lift),
-			fmt.Sprintf("%.6f", rule.LeverageMetric),
-			conviction,
-		}
+	return writer.Flush()
+}
 
-		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("error writing rule: %v", err)
-		}
+// CSVRuleWriter writes rules to CSV one at a time, flushing every flushInterval
+// records so an interrupted run leaves a usable partial file
+type CSVRuleWriter struct {
+	writer    *csv.Writer
+	columns   []ruleColumn
+	style     ItemStyle
+	nonFinite NonFinite
+	pending   int
+}
+
+// NewCSVRuleWriter creates a CSV rule writer on top of w and writes the header
+func NewCSVRuleWriter(w io.Writer, opts CSVOptions) (*CSVRuleWriter, error) {
+	if err := writePreamble(w, opts); err != nil {
+		return nil, err
+	}
+
+	columns, err := layoutRuleColumns(opts.Layout, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ruleHeader(columns)); err != nil {
+		return nil, fmt.Errorf("error writing header: %w", err)
 	}
+	return &CSVRuleWriter{writer: writer, columns: columns, style: opts.ItemStyle, nonFinite: opts.NonFinite}, nil
+}
+
+// Write writes a single rule as one CSV record
+func (w *CSVRuleWriter) Write(rule models.AssociationRule) error {
+	if err := w.writer.Write(ruleRecord(rule, w.columns, w.style, w.nonFinite)); err != nil {
+		return fmt.Errorf("error writing rule: %w", err)
+	}
+
+	w.pending++
+	if w.pending >= flushInterval {
+		return w.Flush()
+	}
+	return nil
+}
 
+// Flush writes any buffered records to the underlying writer
+func (w *CSVRuleWriter) Flush() error {
+	w.pending = 0
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return fmt.Errorf("error flushing output: %w", err)
+	}
 	return nil
 }
 
 // SaveItemsetsToCSV saves frequent itemsets to a CSV file
 func SaveItemsetsToCSV(itemsets []models.FrequentItemset, filePath string) error {
-	file, err := os.Create(filePath)
+	return SaveItemsetsToCSVWithOptions(itemsets, filePath, CSVOptions{})
+}
+
+// SaveItemsetsToCSVWithOptions saves frequent itemsets to a CSV file using the given options
+func SaveItemsetsToCSVWithOptions(itemsets []models.FrequentItemset, filePath string, opts CSVOptions) error {
+	file, err := createCSVFile(filePath, opts)
 	if err != nil {
-		return fmt.Errorf("error creating output file: %v", err)
+		return err
 	}
 	defer file.Close()
 
 	writer := csv.NewWriter(file)
-	defer writer.Flush()
 
 	// Write header
 	header := []string{"support", "itemsets", "length"}
+	if !opts.OmitSupportCount {
+		header = append(header, "support_count")
+	}
 	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("error writing header: %v", err)
+		return fmt.Errorf("error writing header: %w", err)
 	}
 
 	// Write itemsets
-	for _, itemset := range itemsets {
-		itemsetStr := "{" + strings.Join(itemset.Items, ",") + "}"
+	for i, itemset := range itemsets {
+		itemsetStr := opts.ItemStyle.format(itemset.Items)
 
 		record := []string{
-			fmt.Sprintf("%.6f", itemset.Support),
+			opts.NonFinite.format(itemset.Support),
 			itemsetStr,
 			fmt.Sprintf("%d", itemset.Length),
 		}
+		if !opts.OmitSupportCount {
+			record = append(record, fmt.Sprintf("%d", itemset.Count))
+		}
 
 		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("error writing itemset: %v", err)
+			return fmt.Errorf("error writing itemset: %w", err)
+		}
+
+		// Flush periodically so an interrupted run leaves a usable partial file
+		if (i+1)%flushInterval == 0 {
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				return fmt.Errorf("error flushing output: %w", err)
+			}
 		}
 	}
 
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error flushing output: %w", err)
+	}
+
+	return nil
+}
+
+// createCSVFile creates an output file and writes any preamble the options require
+func createCSVFile(filePath string, opts CSVOptions) (*os.File, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error creating output file: %w", err)
+	}
+
+	if err := writePreamble(file, opts); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// writePreamble writes anything the options require before the first record
+func writePreamble(w io.Writer, opts CSVOptions) error {
+	if opts.BOM {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return fmt.Errorf("error writing byte order mark: %w", err)
+		}
+	}
 	return nil
 }
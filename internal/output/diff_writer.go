@@ -0,0 +1,103 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SaveRuleDiffToCSV saves a rule diff report to a CSV file
+func SaveRuleDiffToCSV(diff algorithm.RuleDiff, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	// Write header
+	header := []string{
+		"change", "antecedents", "consequents",
+		"old_support", "new_support",
+		"old_confidence", "new_confidence",
+		"old_lift", "new_lift",
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("error writing header: %w", err)
+	}
+
+	for _, rule := range diff.Added {
+		if err := writer.Write(diffRecord("added", rule, nil, &rule)); err != nil {
+			return fmt.Errorf("error writing rule: %w", err)
+		}
+	}
+
+	for _, rule := range diff.Removed {
+		if err := writer.Write(diffRecord("removed", rule, &rule, nil)); err != nil {
+			return fmt.Errorf("error writing rule: %w", err)
+		}
+	}
+
+	for _, change := range diff.Changed {
+		if err := writer.Write(diffRecord("changed", change.New, &change.Old, &change.New)); err != nil {
+			return fmt.Errorf("error writing rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// PrintRuleDiff prints a human-readable summary of a rule diff
+func PrintRuleDiff(diff algorithm.RuleDiff) {
+	fmt.Printf("Added: %d, Removed: %d, Changed: %d\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+
+	for _, rule := range diff.Added {
+		fmt.Printf("  + %s (confidence=%.4f, lift=%.4f)\n", formatRule(rule), rule.Confidence, rule.Lift)
+	}
+
+	for _, rule := range diff.Removed {
+		fmt.Printf("  - %s (confidence=%.4f, lift=%.4f)\n", formatRule(rule), rule.Confidence, rule.Lift)
+	}
+
+	for _, change := range diff.Changed {
+		fmt.Printf("  ~ %s (confidence %.4f -> %.4f, lift %.4f -> %.4f)\n", formatRule(change.New),
+			change.Old.Confidence, change.New.Confidence, change.Old.Lift, change.New.Lift)
+	}
+}
+
+// diffRecord builds a CSV record for one entry of a rule diff
+func diffRecord(change string, rule models.AssociationRule, oldRule, newRule *models.AssociationRule) []string {
+	record := []string{
+		change,
+		formatItems(rule.Antecedent),
+		formatItems(rule.Consequent),
+	}
+
+	// Metrics missing on one side of the diff are left empty
+	side := func(r *models.AssociationRule, value func(models.AssociationRule) float64) string {
+		if r == nil {
+			return ""
+		}
+		return NonFiniteInf.format(value(*r))
+	}
+
+	support := func(r models.AssociationRule) float64 { return r.Support }
+	confidence := func(r models.AssociationRule) float64 { return r.Confidence }
+	lift := func(r models.AssociationRule) float64 { return r.Lift }
+
+	return append(record,
+		side(oldRule, support), side(newRule, support),
+		side(oldRule, confidence), side(newRule, confidence),
+		side(oldRule, lift), side(newRule, lift),
+	)
+}
+
+// formatRule renders a rule as "{a,b} => {c}"
+func formatRule(rule models.AssociationRule) string {
+	return formatItems(rule.Antecedent) + " => " + formatItems(rule.Consequent)
+}
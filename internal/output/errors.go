@@ -0,0 +1,8 @@
+package output
+
+import (
+	"errors"
+)
+
+// ErrUnknownColumn is returned for a column selection naming a column that does not exist
+var ErrUnknownColumn = errors.New("unknown column")
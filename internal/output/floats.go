@@ -0,0 +1,49 @@
+package output
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// NonFinite selects how infinite and NaN metric values are written to CSV cells
+type NonFinite string
+
+const (
+	NonFiniteInf   NonFinite = "inf"   // inf, -inf and nan, which strconv.ParseFloat reads back
+	NonFiniteEmpty NonFinite = "empty" // An empty cell
+	NonFiniteNull  NonFinite = "null"  // The literal null
+)
+
+// ParseNonFinite converts a style name to a NonFinite
+func ParseNonFinite(name string) (NonFinite, error) {
+	switch style := NonFinite(name); style {
+	case NonFiniteInf, NonFiniteEmpty, NonFiniteNull:
+		return style, nil
+	default:
+		return "", fmt.Errorf("unknown non-finite style %q, must be inf, empty or null", name)
+	}
+}
+
+// format renders a metric with six decimals, writing non-finite values in the style.
+// The zero value writes them like NonFiniteInf.
+func (s NonFinite) format(value float64) string {
+	if !math.IsInf(value, 0) && !math.IsNaN(value) {
+		return strconv.FormatFloat(value, 'f', 6, 64)
+	}
+
+	switch s {
+	case NonFiniteEmpty:
+		return ""
+	case NonFiniteNull:
+		return "null"
+	}
+	switch {
+	case math.IsInf(value, 1):
+		return "inf"
+	case math.IsInf(value, -1):
+		return "-inf"
+	default:
+		return "nan"
+	}
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ItemStyle selects how item lists are rendered in CSV cells
+type ItemStyle string
+
+const (
+	ItemStyleBrace ItemStyle = "brace" // {a,b}, the default
+	ItemStylePipe  ItemStyle = "pipe"  // a|b, with no quoting or escaping
+	ItemStyleJSON  ItemStyle = "json"  // ["a","b"]
+)
+
+// ParseItemStyle converts a style name to an ItemStyle
+func ParseItemStyle(name string) (ItemStyle, error) {
+	switch style := ItemStyle(name); style {
+	case ItemStyleBrace, ItemStylePipe, ItemStyleJSON:
+		return style, nil
+	default:
+		return "", fmt.Errorf("unknown item style %q, must be brace, pipe or json", name)
+	}
+}
+
+// format renders an item list in the style, treating the empty style as brace
+func (s ItemStyle) format(items []string) string {
+	switch s {
+	case ItemStylePipe:
+		return strings.Join(items, "|")
+	case ItemStyleJSON:
+		if items == nil {
+			items = []string{}
+		}
+		encoded, _ := json.Marshal(items)
+		return string(encoded)
+	default:
+		return formatItems(items)
+	}
+}
+
+// formatItems renders an item list as "{a,b,c}". Items containing a delimiter,
+// brace, quote, backslash or surrounding whitespace are wrapped in double quotes
+// with quotes and backslashes escaped, so the list can be parsed back losslessly.
+func formatItems(items []string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, item := range items {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(quoteItem(item))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// quoteItem quotes an item name if it cannot be written bare inside an item list
+func quoteItem(item string) string {
+	if item != "" && !strings.ContainsAny(item, ",{}\"\\") && strings.TrimSpace(item) == item {
+		return item
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range item {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// RuleWriter writes rules one at a time, so they can be saved while they are generated
+type RuleWriter interface {
+	Write(rule models.AssociationRule) error
+	Flush() error
+}
+
+// JSONLRuleWriter writes rules as newline-delimited JSON, one compact object per line
+// in the encoding of models.AssociationRule
+type JSONLRuleWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONLRuleWriter creates a JSONL rule writer on top of w
+func NewJSONLRuleWriter(w io.Writer) *JSONLRuleWriter {
+	writer := bufio.NewWriter(w)
+	return &JSONLRuleWriter{
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// Write encodes a single rule as one JSON line
+func (w *JSONLRuleWriter) Write(rule models.AssociationRule) error {
+	if err := w.encoder.Encode(rule); err != nil {
+		return fmt.Errorf("error writing rule: %w", err)
+	}
+	return nil
+}
+
+// Flush writes any buffered lines to the underlying writer
+func (w *JSONLRuleWriter) Flush() error {
+	return w.writer.Flush()
+}
+
+// SaveRulesToJSONL saves association rules to a newline-delimited JSON file
+func SaveRulesToJSONL(rules []models.AssociationRule, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer file.Close()
+
+	writer := NewJSONLRuleWriter(file)
+	for _, rule := range rules {
+		if err := writer.Write(rule); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error flushing output: %w", err)
+	}
+
+	return nil
+}
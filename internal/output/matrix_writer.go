@@ -0,0 +1,53 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/RiceaRaul/AprioriGO/internal/algorithm"
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// ExportAffinityMatrix writes the pairwise measure of the given items, or of all the
+// dataset's items when items is empty, as a square CSV with the item names as the
+// header row and first column, the layout heatmap plotting functions read directly.
+// Undefined values, such as the lift of an item with itself, are written as nan.
+func ExportAffinityMatrix(dataset *models.Dataset, items []string, measure algorithm.Measure, path string) error {
+	if len(items) == 0 {
+		items = dataset.UniqueItems
+	}
+	matrix, err := algorithm.AffinityMatrix(dataset, items, measure)
+	if err != nil {
+		return err
+	}
+
+	file, err := createCSVFile(path, CSVOptions{})
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := append([]string{""}, items...)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("error writing header: %w", err)
+	}
+
+	var format NonFinite
+	for i, item := range items {
+		record := make([]string, 0, len(items)+1)
+		record = append(record, item)
+		for _, value := range matrix[i] {
+			record = append(record, format.format(value))
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error writing matrix row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error flushing output: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"slices"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// ExportOneHotCSV writes a dataset in the one-hot layout loader.LoadFromOneHotCSV reads
+// and pandas or mlxtend use: a header of every item in sorted order and one 0/1 row per
+// transaction, in transaction order, so the same dataset always gives the same file
+func ExportOneHotCSV(dataset *models.Dataset, path string) error {
+	items := slices.Clone(dataset.UniqueItems)
+	slices.Sort(items)
+	column := make(map[string]int, len(items))
+	for i, item := range items {
+		column[item] = i
+	}
+
+	file, err := createCSVFile(path, CSVOptions{})
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(items); err != nil {
+		return fmt.Errorf("error writing header: %w", err)
+	}
+
+	record := make([]string, len(items))
+	for i, transaction := range dataset.Transactions {
+		for j := range record {
+			record[j] = "0"
+		}
+		for _, item := range transaction {
+			if j, ok := column[item]; ok {
+				record[j] = "1"
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error writing transaction: %w", err)
+		}
+
+		// Flush periodically so an interrupted run leaves a usable partial file
+		if (i+1)%flushInterval == 0 {
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				return fmt.Errorf("error flushing output: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error flushing output: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,293 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// Parquet format constants, from the parquet-format Thrift definitions
+const (
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetRequired = 0
+	parquetRepeated = 2
+
+	parquetUTF8 = 0 // Converted type of a string column
+	parquetList = 3 // Converted type of a list group
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetDataPage = 0
+)
+
+var parquetMagic = []byte("PAR1")
+
+// parquetColumn accumulates the plain-encoded values of one column. A list column
+// holds list<string> items and also records the repetition and definition level of
+// each entry.
+type parquetColumn struct {
+	name     string
+	physical int32
+	list     bool
+
+	values    bytes.Buffer
+	repLevels []int
+	defLevels []int
+	entries   int
+}
+
+func (c *parquetColumn) addDouble(v float64) {
+	c.values.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)))
+	c.entries++
+}
+
+func (c *parquetColumn) addInt64(v int64) {
+	c.values.Write(binary.LittleEndian.AppendUint64(nil, uint64(v)))
+	c.entries++
+}
+
+// addList appends one row's list; an empty list is a single entry with no value
+func (c *parquetColumn) addList(items []string) {
+	if len(items) == 0 {
+		c.repLevels = append(c.repLevels, 0)
+		c.defLevels = append(c.defLevels, 0)
+		c.entries++
+		return
+	}
+	for i, item := range items {
+		c.repLevels = append(c.repLevels, min(i, 1))
+		c.defLevels = append(c.defLevels, 1)
+		c.values.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(item))))
+		c.values.WriteString(item)
+		c.entries++
+	}
+}
+
+// path is the column's path in the schema, through the list's inner groups
+func (c *parquetColumn) path() []string {
+	if c.list {
+		return []string{c.name, "list", "element"}
+	}
+	return []string{c.name}
+}
+
+// page encodes the column as the body of a single data page
+func (c *parquetColumn) page() []byte {
+	var page bytes.Buffer
+	if c.list {
+		writeLevels(&page, c.repLevels)
+		writeLevels(&page, c.defLevels)
+	}
+	page.Write(c.values.Bytes())
+	return page.Bytes()
+}
+
+// writeLevels writes levels of at most 1 in the RLE hybrid encoding, one run per
+// stretch of equal levels, prefixed by the byte length as data pages require
+func writeLevels(page *bytes.Buffer, levels []int) {
+	var encoded []byte
+	for start := 0; start < len(levels); {
+		end := start
+		for end < len(levels) && levels[end] == levels[start] {
+			end++
+		}
+		encoded = binary.AppendUvarint(encoded, uint64(end-start)<<1)
+		encoded = append(encoded, byte(levels[start]))
+		start = end
+	}
+	page.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(encoded))))
+	page.Write(encoded)
+}
+
+// SaveRulesToParquet saves association rules to a Parquet file: the antecedents and
+// consequents as list<string> columns, the metrics as double and the counts as int64.
+// Infinite convictions are stored as infinity. The file is uncompressed and holds a
+// single row group, so it is built in memory before it is written.
+func SaveRulesToParquet(rules []models.AssociationRule, filePath string) error {
+	columns := []*parquetColumn{
+		{name: "antecedents", physical: parquetByteArray, list: true},
+		{name: "consequents", physical: parquetByteArray, list: true},
+		{name: "support", physical: parquetDouble},
+		{name: "confidence", physical: parquetDouble},
+		{name: "lift", physical: parquetDouble},
+		{name: "leverage", physical: parquetDouble},
+		{name: "conviction", physical: parquetDouble},
+		{name: "antecedent_support", physical: parquetDouble},
+		{name: "consequent_support", physical: parquetDouble},
+		{name: "antecedent_count", physical: parquetInt64},
+		{name: "itemset_count", physical: parquetInt64},
+		{name: "transaction_count", physical: parquetInt64},
+		{name: "revenue_score", physical: parquetDouble},
+	}
+	for _, rule := range rules {
+		columns[0].addList(rule.Antecedent)
+		columns[1].addList(rule.Consequent)
+		columns[2].addDouble(rule.Support)
+		columns[3].addDouble(rule.Confidence)
+		columns[4].addDouble(rule.Lift)
+		columns[5].addDouble(rule.LeverageMetric)
+		columns[6].addDouble(rule.ConvictionMetric)
+		columns[7].addDouble(rule.AntecedentSupport)
+		columns[8].addDouble(rule.ConsequentSupport)
+		columns[9].addInt64(int64(rule.AntecedentCount))
+		columns[10].addInt64(int64(rule.ItemsetCount))
+		columns[11].addInt64(int64(rule.TransactionCount))
+		columns[12].addDouble(rule.RevenueScore)
+	}
+	return writeParquetFile(filePath, columns, len(rules))
+}
+
+// SaveItemsetsToParquet saves frequent itemsets to a Parquet file with the items as a
+// list<string> column, the support as double and the length and count as int64
+func SaveItemsetsToParquet(itemsets []models.FrequentItemset, filePath string) error {
+	columns := []*parquetColumn{
+		{name: "itemsets", physical: parquetByteArray, list: true},
+		{name: "support", physical: parquetDouble},
+		{name: "length", physical: parquetInt64},
+		{name: "support_count", physical: parquetInt64},
+	}
+	for _, itemset := range itemsets {
+		columns[0].addList(itemset.Items)
+		columns[1].addDouble(itemset.Support)
+		columns[2].addInt64(int64(itemset.Length))
+		columns[3].addInt64(int64(itemset.Count))
+	}
+	return writeParquetFile(filePath, columns, len(itemsets))
+}
+
+// parquetChunk records where a column's data page was written
+type parquetChunk struct {
+	offset int64
+	size   int64
+}
+
+// writeParquetFile writes the columns as one row group of rows rows, each column as a
+// single plain-encoded data page, followed by the footer
+func writeParquetFile(filePath string, columns []*parquetColumn, rows int) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	writer.Write(parquetMagic)
+	offset := int64(len(parquetMagic))
+
+	chunks := make([]parquetChunk, len(columns))
+	for i, column := range columns {
+		page := column.page()
+		header := pageHeader(column, len(page))
+		writer.Write(header)
+		writer.Write(page)
+
+		chunks[i] = parquetChunk{offset: offset, size: int64(len(header) + len(page))}
+		offset += chunks[i].size
+	}
+
+	footer := fileMetadata(columns, chunks, rows)
+	writer.Write(footer)
+	writer.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer))))
+	writer.Write(parquetMagic)
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+	return file.Close()
+}
+
+// pageHeader encodes the header of a column's uncompressed data page
+func pageHeader(column *parquetColumn, size int) []byte {
+	var w thriftWriter
+	w.beginStruct()
+	w.i32(1, parquetDataPage)
+	w.i32(2, int32(size)) // Uncompressed size
+	w.i32(3, int32(size)) // Compressed size
+	w.structField(5, func() {
+		w.i32(1, int32(column.entries))
+		w.i32(2, parquetPlain)
+		w.i32(3, parquetRLE) // Definition levels
+		w.i32(4, parquetRLE) // Repetition levels
+	})
+	w.endStruct()
+	return w.buf.Bytes()
+}
+
+// fileMetadata encodes the footer: the schema and the location of every column chunk
+func fileMetadata(columns []*parquetColumn, chunks []parquetChunk, rows int) []byte {
+	type schemaElement struct {
+		physical, repetition, children, converted int32 // -1 when unset
+		name                                      string
+	}
+	schema := []schemaElement{{physical: -1, repetition: -1, children: int32(len(columns)), converted: -1, name: "schema"}}
+	for _, column := range columns {
+		if column.list {
+			schema = append(schema,
+				schemaElement{physical: -1, repetition: parquetRequired, children: 1, converted: parquetList, name: column.name},
+				schemaElement{physical: -1, repetition: parquetRepeated, children: 1, converted: -1, name: "list"},
+				schemaElement{physical: column.physical, repetition: parquetRequired, children: -1, converted: parquetUTF8, name: "element"})
+		} else {
+			schema = append(schema, schemaElement{physical: column.physical, repetition: parquetRequired, children: -1, converted: -1, name: column.name})
+		}
+	}
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.size
+	}
+
+	var w thriftWriter
+	w.beginStruct()
+	w.i32(1, 1) // Format version
+	w.structList(2, len(schema), func(i int) {
+		element := schema[i]
+		if element.physical >= 0 {
+			w.i32(1, element.physical)
+		}
+		if element.repetition >= 0 {
+			w.i32(3, element.repetition)
+		}
+		w.string(4, element.name)
+		if element.children >= 0 {
+			w.i32(5, element.children)
+		}
+		if element.converted >= 0 {
+			w.i32(6, element.converted)
+		}
+	})
+	w.i64(3, int64(rows))
+	w.structList(4, 1, func(int) {
+		w.structList(1, len(columns), func(i int) {
+			column, chunk := columns[i], chunks[i]
+			w.i64(2, chunk.offset)
+			w.structField(3, func() {
+				encodings := []int32{parquetPlain}
+				if column.list {
+					encodings = append(encodings, parquetRLE)
+				}
+				w.i32(1, column.physical)
+				w.i32List(2, encodings)
+				w.stringList(3, column.path())
+				w.i32(4, 0) // Uncompressed
+				w.i64(5, int64(column.entries))
+				w.i64(6, chunk.size)
+				w.i64(7, chunk.size)
+				w.i64(9, chunk.offset)
+			})
+		})
+		w.i64(2, totalSize)
+		w.i64(3, int64(rows))
+	})
+	w.string(6, "AprioriGO")
+	w.endStruct()
+	return w.buf.Bytes()
+}
@@ -0,0 +1,168 @@
+package output
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// thriftReader decodes the Thrift compact protocol into generic values: integers as
+// int64, binaries as []byte, lists as []any and structs as map[int16]any
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		panic("bad varint")
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case thriftI32, thriftI64:
+		v, n := binary.Varint(r.data[r.pos:])
+		if n <= 0 {
+			panic("bad varint")
+		}
+		r.pos += n
+		return v
+	case thriftBinary:
+		size := int(r.uvarint())
+		b := r.data[r.pos : r.pos+size]
+		r.pos += size
+		return b
+	case thriftList:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case thriftStruct:
+		return r.structValue()
+	default:
+		panic(fmt.Sprintf("unexpected type %d", typ))
+	}
+}
+
+func (r *thriftReader) structValue() map[int16]any {
+	fields := make(map[int16]any)
+	var id int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		if delta := int16(header >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, n := binary.Varint(r.data[r.pos:])
+			r.pos += n
+			id = int16(v)
+		}
+		fields[id] = r.value(header & 0x0f)
+	}
+}
+
+// readParquetFooter checks the magic bytes around a Parquet file and decodes its footer
+func readParquetFooter(t *testing.T, data []byte) map[int16]any {
+	t.Helper()
+	if len(data) < 12 || !bytes.Equal(data[:4], parquetMagic) || !bytes.Equal(data[len(data)-4:], parquetMagic) {
+		t.Fatalf("file does not start and end with %q", parquetMagic)
+	}
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	start := len(data) - 8 - size
+	if start < 4 {
+		t.Fatalf("footer length %d does not fit the %d byte file", size, len(data))
+	}
+	r := &thriftReader{data: data[:len(data)-8], pos: start}
+	footer := r.structValue()
+	if r.pos != len(data)-8 {
+		t.Fatalf("footer decoded to byte %d, want its length %d to end at %d", r.pos, size, len(data)-8)
+	}
+	return footer
+}
+
+func TestSaveRulesToParquet(t *testing.T) {
+	rules := []models.AssociationRule{
+		{Antecedent: []string{}, Consequent: []string{"milk"}, Support: 0.5, Confidence: 0.5, Lift: 1, ConvictionMetric: 1, ItemsetCount: 2, TransactionCount: 4},
+		{Antecedent: []string{"bread", "eggs"}, Consequent: []string{"milk"}, Support: 0.25, Confidence: 1, Lift: 2, ConvictionMetric: math.Inf(1), AntecedentCount: 1, ItemsetCount: 1, TransactionCount: 4},
+	}
+	path := filepath.Join(t.TempDir(), "rules.parquet")
+	if err := SaveRulesToParquet(rules, path); err != nil {
+		t.Fatalf("SaveRulesToParquet: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	footer := readParquetFooter(t, data)
+	if rows := footer[3]; rows != int64(len(rules)) {
+		t.Errorf("num_rows = %v, want %d", rows, len(rules))
+	}
+	leaves := make([]string, 0)
+	for _, element := range footer[2].([]any) {
+		fields := element.(map[int16]any)
+		if _, ok := fields[1]; ok { // Only leaves have a physical type
+			leaves = append(leaves, string(fields[4].([]byte)))
+		}
+	}
+	if len(leaves) != 13 {
+		t.Fatalf("schema has %d leaf columns %v, want 13", len(leaves), leaves)
+	}
+
+	rowGroups := footer[4].([]any)
+	if len(rowGroups) != 1 {
+		t.Fatalf("got %d row groups, want 1", len(rowGroups))
+	}
+	rowGroup := rowGroups[0].(map[int16]any)
+	chunks := rowGroup[1].([]any)
+	if len(chunks) != len(leaves) || rowGroup[3] != int64(len(rules)) {
+		t.Fatalf("row group has %d columns and %v rows, want %d and %d", len(chunks), rowGroup[3], len(leaves), len(rules))
+	}
+
+	// The empty antecedent list is a single entry with no value
+	antecedents := chunks[0].(map[int16]any)[3].(map[int16]any)
+	if values := antecedents[5]; values != int64(3) {
+		t.Errorf("antecedents column has %v values, want 3 for an empty and a two-item list", values)
+	}
+
+	// Read the conviction column, the seventh, from its data page
+	conviction := chunks[6].(map[int16]any)[3].(map[int16]any)
+	r := &thriftReader{data: data, pos: int(conviction[9].(int64))}
+	header := r.structValue()
+	if header[1] != int64(parquetDataPage) {
+		t.Fatalf("conviction page type = %v, want a data page", header[1])
+	}
+	values := data[r.pos : r.pos+int(header[2].(int64))]
+	if len(values) != 16 {
+		t.Fatalf("conviction page holds %d bytes, want 2 doubles", len(values))
+	}
+	for i, want := range []float64{1, math.Inf(1)} {
+		if got := math.Float64frombits(binary.LittleEndian.Uint64(values[8*i:])); got != want {
+			t.Errorf("conviction of row %d = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/RiceaRaul/AprioriGO/internal/models"
+)
+
+// SaveRulesToSQLite writes rules to a SQLite table, creating it if it does not exist.
+// Item lists are stored as JSON array text and metrics as REAL, with NULL for an
+// infinite conviction. All rows are inserted in one transaction. The caller opens the
+// *sql.DB with a driver of their choice, so no database driver is linked into this package.
+func SaveRulesToSQLite(db *sql.DB, table string, rules []models.AssociationRule) error {
+	columns := []string{
+		"antecedents TEXT NOT NULL",
+		"consequents TEXT NOT NULL",
+		"support REAL",
+		"confidence REAL",
+		"lift REAL",
+		"leverage REAL",
+		"conviction REAL",
+		"antecedent_count INTEGER",
+		"itemset_count INTEGER",
+		"transaction_count INTEGER",
+		"revenue_score REAL",
+	}
+
+	return insertRows(db, table, columns, len(rules), func(i int) []any {
+		rule := rules[i]
+		conviction := sql.NullFloat64{Float64: rule.ConvictionMetric, Valid: !math.IsInf(rule.ConvictionMetric, 0)}
+		return []any{
+			ItemStyleJSON.format(rule.Antecedent), ItemStyleJSON.format(rule.Consequent),
+			rule.Support, rule.Confidence, rule.Lift, rule.LeverageMetric, conviction,
+			rule.AntecedentCount, rule.ItemsetCount, rule.TransactionCount, rule.RevenueScore,
+		}
+	})
+}
+
+// SaveItemsetsToSQLite writes frequent itemsets to a SQLite table, creating it if it
+// does not exist, with the items stored as JSON array text
+func SaveItemsetsToSQLite(db *sql.DB, table string, itemsets []models.FrequentItemset) error {
+	columns := []string{
+		"itemsets TEXT NOT NULL",
+		"support REAL",
+		"length INTEGER",
+		"support_count INTEGER",
+	}
+
+	return insertRows(db, table, columns, len(itemsets), func(i int) []any {
+		itemset := itemsets[i]
+		return []any{ItemStyleJSON.format(itemset.Items), itemset.Support, itemset.Length, itemset.Count}
+	})
+}
+
+// insertRows creates a table from column definitions and inserts n rows built by row
+// in a single transaction, rolling back on the first error
+func insertRows(db *sql.DB, table string, columns []string, n int, row func(i int) []any) error {
+	name := quoteIdentifier(table)
+	if _, err := db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", name, strings.Join(columns, ", "))); err != nil {
+		return fmt.Errorf("error creating table: %w", err)
+	}
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i], _, _ = strings.Cut(column, " ")
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", name, strings.Join(names, ", "), placeholders)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("error preparing insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i := 0; i < n; i++ {
+		if _, err := stmt.Exec(row(i)...); err != nil {
+			return fmt.Errorf("error inserting row: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// quoteIdentifier quotes a table name so it is used verbatim in SQL
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
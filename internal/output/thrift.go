@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Type codes of the Thrift compact protocol used by the Parquet metadata
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs in the Thrift compact protocol. Fields are written in
+// increasing id order and nested structs are bracketed by beginStruct and endStruct.
+type thriftWriter struct {
+	buf    bytes.Buffer
+	lastID int16
+	stack  []int16
+}
+
+// field writes a field header, as a delta from the previous field id when it fits
+func (w *thriftWriter) field(id int16, typ byte) {
+	if delta := id - w.lastID; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	w.lastID = id
+}
+
+// varint writes a zigzag-encoded variable-length integer
+func (w *thriftWriter) varint(v int64) {
+	w.buf.Write(binary.AppendVarint(nil, v))
+}
+
+// bytes writes a length-prefixed byte string
+func (w *thriftWriter) bytes(b []byte) {
+	w.buf.Write(binary.AppendUvarint(nil, uint64(len(b))))
+	w.buf.Write(b)
+}
+
+// listHeader writes the size and element type of a list
+func (w *thriftWriter) listHeader(typ byte, size int) {
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | typ)
+		return
+	}
+	w.buf.WriteByte(0xf0 | typ)
+	w.buf.Write(binary.AppendUvarint(nil, uint64(size)))
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) string(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.bytes([]byte(s))
+}
+
+func (w *thriftWriter) i32List(id int16, values []int32) {
+	w.field(id, thriftList)
+	w.listHeader(thriftI32, len(values))
+	for _, v := range values {
+		w.varint(int64(v))
+	}
+}
+
+func (w *thriftWriter) stringList(id int16, values []string) {
+	w.field(id, thriftList)
+	w.listHeader(thriftBinary, len(values))
+	for _, v := range values {
+		w.bytes([]byte(v))
+	}
+}
+
+// structList writes a list of n structs, each written by element between its own
+// beginStruct and endStruct
+func (w *thriftWriter) structList(id int16, n int, element func(i int)) {
+	w.field(id, thriftList)
+	w.listHeader(thriftStruct, n)
+	for i := 0; i < n; i++ {
+		w.beginStruct()
+		element(i)
+		w.endStruct()
+	}
+}
+
+// structField writes a struct-valued field
+func (w *thriftWriter) structField(id int16, body func()) {
+	w.field(id, thriftStruct)
+	w.beginStruct()
+	body()
+	w.endStruct()
+}
+
+// beginStruct starts numbering the fields of a nested struct afresh
+func (w *thriftWriter) beginStruct() {
+	w.stack = append(w.stack, w.lastID)
+	w.lastID = 0
+}
+
+// endStruct writes the stop byte of a struct and returns to the enclosing one
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0)
+	w.lastID = w.stack[len(w.stack)-1]
+	w.stack = w.stack[:len(w.stack)-1]
+}